@@ -0,0 +1,230 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hezhizhen/sak/pkg/bookmark"
+	"github.com/hezhizhen/sak/pkg/browser"
+	"github.com/hezhizhen/sak/pkg/dryrun"
+	"github.com/hezhizhen/sak/pkg/exit"
+	"github.com/hezhizhen/sak/pkg/i18n"
+	"github.com/hezhizhen/sak/pkg/output"
+	"github.com/hezhizhen/sak/pkg/table"
+
+	"github.com/spf13/cobra"
+)
+
+func bookmarkCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "bm",
+		Aliases: []string{"bookmark"},
+		Short:   i18n.T("Manage bookmarks"),
+		Long: i18n.T(`Manage bookmarks
+
+Bookmarks are stored in bookmarks.json next to the configuration file, so
+they can be synced along with it. The page title is fetched when a
+bookmark is added without --title.
+
+Example - bookmark a page with tags:
+  sak bm add https://go.dev/doc/effective_go --tags go,docs
+
+Example - find bookmarks about cobra:
+  sak bm search cobra
+
+Example - open bookmark 3 in the browser:
+  sak bm open 3
+`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(bookmarkAddCmd())
+	cmd.AddCommand(bookmarkListCmd())
+	cmd.AddCommand(bookmarkSearchCmd())
+	cmd.AddCommand(bookmarkOpenCmd())
+	cmd.AddCommand(bookmarkRemoveCmd())
+
+	return cmd
+}
+
+func bookmarkAddCmd() *cobra.Command {
+	var (
+		title string
+		tags  []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "add <url>",
+		Short: i18n.T("Add a bookmark"),
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			url, err := bookmark.NormalizeURL(args[0])
+			if err != nil {
+				return err
+			}
+			bookmarks, err := bookmark.Load()
+			if err != nil {
+				return err
+			}
+			for _, b := range bookmarks {
+				if b.URL == url {
+					return exit.New(exit.Usage, i18n.Errorf("%s is already bookmarked as %d", url, b.ID))
+				}
+			}
+
+			if title == "" {
+				ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Second)
+				defer cancel()
+				if title, err = bookmark.FetchTitle(ctx, url); err != nil {
+					fmt.Fprintln(os.Stderr, i18n.Sprintf("warning: could not fetch the title: %v", err))
+				}
+			}
+			b := bookmark.Bookmark{
+				ID:    bookmark.NextID(bookmarks),
+				URL:   url,
+				Title: title,
+				Tags:  cleanTags(tags),
+				Added: time.Now(),
+			}
+			if err := bookmark.Save(cmd.Context(), append(bookmarks, b)); err != nil {
+				return err
+			}
+			return render(cmd, bookmarkList{b})
+		},
+	}
+
+	cmd.Flags().StringVar(&title, "title", "", i18n.T("title of the bookmark instead of the page title"))
+	cmd.Flags().StringSliceVar(&tags, "tags", nil, i18n.T("comma-separated tags"))
+
+	return cmd
+}
+
+func bookmarkListCmd() *cobra.Command {
+	var tag string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: i18n.T("List bookmarks"),
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			bookmarks, err := bookmark.Load()
+			if err != nil {
+				return err
+			}
+			list := bookmarkList{}
+			for _, b := range bookmarks {
+				if tag == "" || b.HasTag(tag) {
+					list = append(list, b)
+				}
+			}
+			return render(cmd, list)
+		},
+	}
+
+	cmd.Flags().StringVar(&tag, "tag", "", i18n.T("only list bookmarks with this tag"))
+
+	return cmd
+}
+
+func bookmarkSearchCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "search <query...>",
+		Short: i18n.T("Search bookmarks by title, URL and tags"),
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			bookmarks, err := bookmark.Load()
+			if err != nil {
+				return err
+			}
+			query := strings.Join(args, " ")
+			list := bookmarkList{}
+			for _, b := range bookmarks {
+				if b.Matches(query) {
+					list = append(list, b)
+				}
+			}
+			if len(list) == 0 {
+				return exit.New(exit.NotFound, i18n.Errorf("no bookmarks match %q", query))
+			}
+			return render(cmd, list)
+		},
+	}
+}
+
+func bookmarkOpenCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "open <id>",
+		Short: i18n.T("Open a bookmark in the browser"),
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			bookmarks, i, err := loadBookmark(args[0])
+			if err != nil {
+				return err
+			}
+			return dryrun.Run(cmd.Context(), browser.Command(bookmarks[i].URL))
+		},
+	}
+}
+
+func bookmarkRemoveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "rm <id>",
+		Short: i18n.T("Remove a bookmark"),
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			bookmarks, i, err := loadBookmark(args[0])
+			if err != nil {
+				return err
+			}
+			return bookmark.Save(cmd.Context(), append(bookmarks[:i], bookmarks[i+1:]...))
+		},
+	}
+}
+
+// loadBookmark loads the bookmarks and finds the one with the ID given as
+// an argument
+func loadBookmark(arg string) ([]bookmark.Bookmark, int, error) {
+	id, err := strconv.Atoi(arg)
+	if err != nil {
+		return nil, 0, exit.New(exit.Usage, i18n.Errorf("invalid bookmark ID %q", arg))
+	}
+	bookmarks, err := bookmark.Load()
+	if err != nil {
+		return nil, 0, err
+	}
+	i, err := bookmark.Find(bookmarks, id)
+	return bookmarks, i, err
+}
+
+// cleanTags trims the tags and drops empty and duplicate ones
+func cleanTags(tags []string) []string {
+	var cleaned []string
+	seen := map[string]bool{}
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag != "" && !seen[tag] {
+			seen[tag] = true
+			cleaned = append(cleaned, tag)
+		}
+	}
+	return cleaned
+}
+
+type bookmarkList []bookmark.Bookmark
+
+func (list bookmarkList) WriteTable(w io.Writer, opts output.Options) error {
+	t := table.New(i18n.T("ID"), i18n.T("TITLE"), i18n.T("URL"), i18n.T("TAGS"))
+	t.SetAlign(0, table.Right)
+	for _, b := range list {
+		t.AddRow(strconv.Itoa(b.ID), b.Title, b.URL, strings.Join(b.Tags, ","))
+	}
+	return t.Render(w, opts)
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/hezhizhen/sak/pkg/exit"
+	"github.com/hezhizhen/sak/pkg/habit"
+	"github.com/hezhizhen/sak/pkg/i18n"
+	"github.com/hezhizhen/sak/pkg/note"
+	"github.com/hezhizhen/sak/pkg/output"
+	"github.com/hezhizhen/sak/pkg/utils"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/spf13/cobra"
+)
+
+// Marks following the days of the calendar
+const (
+	calNoteMark  = "•"
+	calHabitMark = "✓"
+)
+
+// calToday is the color of today, reverse video
+const calToday output.Color = "7"
+
+func calCmd() *cobra.Command {
+	var month, habitName string
+
+	cmd := &cobra.Command{
+		Use:   "cal",
+		Short: i18n.T("Show a calendar of a month with notes and habits"),
+		Long: i18n.T(`Show a calendar of a month with notes and habits
+
+Weeks start on Monday and today is highlighted. Days on which notes were
+captured with sak note are marked with •. With --habit, the days on which
+the habit was checked are marked with ✓.
+
+Example - show the current month:
+  sak cal
+
+Example - show July 2025 with the days of a habit:
+  sak cal --month 2025-07 --habit exercise
+`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			now := time.Now()
+			// any date-range expression covering exactly one month
+			first, end, err := utils.ParseDateRange(month, now)
+			if err != nil || first.Day() != 1 || !end.Equal(first.AddDate(0, 1, 0)) {
+				return exit.New(exit.Usage, i18n.Errorf("invalid month %q (expected e.g. 2025-07, this-month or last-month)", month))
+			}
+
+			c := calMonth{
+				Month: first.Format("2006-01"),
+				Days:  []calDay{},
+				first: first,
+				today: now,
+				habit: habitName,
+			}
+			notes, err := note.Load()
+			if err != nil {
+				return err
+			}
+			counts := map[string]int{}
+			for _, n := range notes {
+				counts[n.Time.Format(habit.DateLayout)]++
+			}
+			var tracker *habit.Tracker
+			if habitName != "" {
+				if tracker, err = habit.Load(); err != nil {
+					return err
+				}
+				if !tracker.Has(habitName) {
+					return exit.New(exit.NotFound, i18n.Errorf("unknown habit %q", habitName))
+				}
+			}
+
+			for day := first; day.Month() == first.Month(); day = day.AddDate(0, 0, 1) {
+				d := calDay{Date: day.Format(habit.DateLayout), Notes: counts[day.Format(habit.DateLayout)]}
+				if tracker != nil {
+					done := tracker.Done(habitName, day)
+					d.Habit = &done
+				}
+				c.Days = append(c.Days, d)
+			}
+			return render(cmd, c)
+		},
+	}
+
+	cmd.Flags().StringVar(&month, "month", "this-month", i18n.T("month to show, e.g. 2025-07 or last-month"))
+	cmd.Flags().StringVar(&habitName, "habit", "", i18n.T("mark the days on which this habit was checked"))
+	_ = cmd.RegisterFlagCompletionFunc("habit", completeHabits)
+
+	return cmd
+}
+
+// monthNames returns the names of the months, January first
+func monthNames() []string {
+	return []string{
+		i18n.T("January"), i18n.T("February"), i18n.T("March"), i18n.T("April"),
+		i18n.T("May"), i18n.T("June"), i18n.T("July"), i18n.T("August"),
+		i18n.T("September"), i18n.T("October"), i18n.T("November"), i18n.T("December"),
+	}
+}
+
+type calDay struct {
+	Date  string `json:"date" yaml:"date"`
+	Notes int    `json:"notes" yaml:"notes"`
+	Habit *bool  `json:"habit,omitempty" yaml:"habit,omitempty"`
+}
+
+type calMonth struct {
+	Month string   `json:"month" yaml:"month"`
+	Days  []calDay `json:"days" yaml:"days"`
+
+	first time.Time
+	today time.Time
+	habit string
+}
+
+func (c calMonth) WriteTable(w io.Writer, opts output.Options) error {
+	labels := weekdayLabels()
+	// two digits and the marks
+	width := 3
+	if c.habit != "" {
+		width = 4
+	}
+	for _, label := range labels {
+		if lw := runewidth.StringWidth(label); lw > width {
+			width = lw
+		}
+	}
+	cells := make([]string, len(labels))
+	for i, label := range labels {
+		cells[i] = runewidth.FillRight(label, width)
+	}
+	header := strings.TrimRight(strings.Join(cells, " "), " ")
+
+	title := i18n.Sprintf("%[1]s %[2]d", monthNames()[c.first.Month()-1], c.first.Year())
+	if pad := (runewidth.StringWidth(header) - runewidth.StringWidth(title)) / 2; pad > 0 {
+		title = strings.Repeat(" ", pad) + title
+	}
+	fmt.Fprintln(w, opts.Style(output.Heading, title))
+	fmt.Fprintln(w, header)
+
+	// the week row starts on Monday
+	offset := (int(c.first.Weekday()) + 6) % 7
+	row := make([]string, offset)
+	for i := range row {
+		row[i] = strings.Repeat(" ", width)
+	}
+	for i, d := range c.Days {
+		number := fmt.Sprintf("%2d", i+1)
+		switch {
+		case d.Habit != nil && *d.Habit:
+			number = opts.Style(output.Success, number)
+		case d.Notes > 0:
+			number = opts.Style(output.Accent, number)
+		}
+		if d.Date == c.today.Format(habit.DateLayout) {
+			number = opts.Colorize(calToday, number)
+		}
+		// padded by hand as the colors would count towards the width
+		cell, cellWidth := number+calMark(d.Notes > 0, calNoteMark), 3
+		if d.Habit != nil {
+			cell, cellWidth = cell+calMark(*d.Habit, calHabitMark), 4
+		}
+		row = append(row, cell+strings.Repeat(" ", width-cellWidth))
+		if len(row) == 7 || i == len(c.Days)-1 {
+			fmt.Fprintln(w, strings.TrimRight(strings.Join(row, " "), " "))
+			row = row[:0]
+		}
+	}
+
+	fmt.Fprintln(w)
+	legend := opts.Style(output.Accent, calNoteMark) + " " + i18n.T("notes")
+	if c.habit != "" {
+		legend += "  " + opts.Style(output.Success, calHabitMark) + " " + c.habit
+	}
+	fmt.Fprintln(w, legend)
+	return nil
+}
+
+// calMark returns s if set, or else a space of the same width
+func calMark(set bool, s string) string {
+	if set {
+		return s
+	}
+	return strings.Repeat(" ", runewidth.StringWidth(s))
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hezhizhen/sak/pkg/clipboard"
+	"github.com/hezhizhen/sak/pkg/config"
+	"github.com/hezhizhen/sak/pkg/exit"
+	"github.com/hezhizhen/sak/pkg/i18n"
+	"github.com/hezhizhen/sak/pkg/output"
+	"github.com/hezhizhen/sak/pkg/table"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/spf13/cobra"
+)
+
+func clipCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "clip",
+		Short: i18n.T("Copy to and paste from the system clipboard"),
+		Long: i18n.T(`Copy to and paste from the system clipboard
+
+The clipboard is accessed with pbcopy/pbpaste on macOS, clip and
+PowerShell on Windows, and wl-clipboard, xclip or xsel elsewhere.
+
+Texts copied with sak clip copy are remembered when the clip-history config
+key is set to the number of texts to keep. The history is stored in
+sak/clipboard.jsonl under $XDG_STATE_HOME (default ~/.local/state).
+
+Example - copy the output of a command:
+  git rev-parse HEAD | sak clip copy
+
+Example - remember the last 20 copies and list them:
+  sak config set clip-history 20
+  sak clip history
+
+Example - copy history entry 3 again:
+  sak clip restore 3
+`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(clipCopyCmd())
+	cmd.AddCommand(clipPasteCmd())
+	cmd.AddCommand(clipHistoryCmd())
+	cmd.AddCommand(clipRestoreCmd())
+
+	return cmd
+}
+
+func clipCopyCmd() *cobra.Command {
+	var trim bool
+
+	cmd := &cobra.Command{
+		Use:   "copy [text...]",
+		Short: i18n.T("Copy the arguments or stdin to the clipboard"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			text := strings.Join(args, " ")
+			if len(args) == 0 {
+				data, err := io.ReadAll(os.Stdin)
+				if err != nil {
+					return err
+				}
+				text = string(data)
+			}
+			if trim {
+				text = strings.TrimRight(text, "\r\n")
+			}
+			if err := clipboard.Copy(text); err != nil {
+				return err
+			}
+
+			c, err := config.Load()
+			if err != nil {
+				return err
+			}
+			if size := c.ClipHistorySize(); size > 0 && text != "" {
+				return clipboard.Remember(cmd.Context(), clipboard.Entry{Time: time.Now(), Text: text}, size)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&trim, "trim", "t", false, i18n.T("remove trailing line breaks"))
+
+	return cmd
+}
+
+func clipPasteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "paste",
+		Short: i18n.T("Print the clipboard"),
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			text, err := clipboard.Paste()
+			if err != nil {
+				return err
+			}
+			fmt.Print(text)
+			return nil
+		},
+	}
+}
+
+func clipHistoryCmd() *cobra.Command {
+	var clear bool
+
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: i18n.T("List the remembered copies"),
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if clear {
+				return clipboard.ClearHistory(cmd.Context())
+			}
+			entries, err := clipboard.History()
+			if err != nil {
+				return err
+			}
+			list := clipList{}
+			for i, e := range entries {
+				list = append(list, clipEntry{ID: i + 1, Entry: e})
+			}
+			return render(cmd, list)
+		},
+	}
+
+	cmd.Flags().BoolVar(&clear, "clear", false, i18n.T("forget all remembered copies"))
+
+	return cmd
+}
+
+func clipRestoreCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "restore <id>",
+		Short: i18n.T("Copy a remembered text to the clipboard again"),
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.Atoi(args[0])
+			if err != nil {
+				return exit.New(exit.Usage, i18n.Errorf("invalid history ID %q", args[0]))
+			}
+			entries, err := clipboard.History()
+			if err != nil {
+				return err
+			}
+			if id < 1 || id > len(entries) {
+				return exit.New(exit.NotFound, i18n.Errorf("no clipboard history entry with ID %d", id))
+			}
+			return clipboard.Copy(entries[id-1].Text)
+		},
+	}
+}
+
+// clipPreviewWidth is the width of the text column of the clipboard history
+const clipPreviewWidth = 60
+
+type clipEntry struct {
+	ID              int `json:"id" yaml:"id"`
+	clipboard.Entry `yaml:",inline"`
+}
+
+type clipList []clipEntry
+
+func (list clipList) WriteTable(w io.Writer, opts output.Options) error {
+	t := table.New(i18n.T("ID"), i18n.T("TIME"), i18n.T("TEXT"))
+	t.SetAlign(0, table.Right)
+	for _, e := range list {
+		preview := runewidth.Truncate(strings.Join(strings.Fields(e.Text), " "), clipPreviewWidth, "…")
+		t.AddRow(strconv.Itoa(e.ID), e.Time.Local().Format("2006-01-02 15:04:05"), preview)
+	}
+	return t.Render(w, opts)
+}
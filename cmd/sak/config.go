@@ -0,0 +1,226 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/hezhizhen/sak/pkg/config"
+	"github.com/hezhizhen/sak/pkg/dryrun"
+	"github.com/hezhizhen/sak/pkg/exit"
+	"github.com/hezhizhen/sak/pkg/i18n"
+	"github.com/hezhizhen/sak/pkg/output"
+	"github.com/hezhizhen/sak/pkg/table"
+
+	"github.com/spf13/cobra"
+)
+
+func configCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: i18n.T("Read and modify the sak configuration"),
+		Long: i18n.T(`Read and modify the sak configuration
+
+The configuration file is read from $SAK_CONFIG, or sak/config.yaml under
+$XDG_CONFIG_HOME (default ~/.config).
+
+Example - list all known keys and their values:
+  sak config list
+
+Example - set the editor used by sak:
+  sak config set editor "code --wait"
+
+Example - set the editor of the work profile only:
+  sak --profile work config set editor "code --wait"
+`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(configGetCmd())
+	cmd.AddCommand(configSetCmd())
+	cmd.AddCommand(configListCmd())
+	cmd.AddCommand(configEditCmd())
+
+	return cmd
+}
+
+func configGetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:       "get <key>",
+		Short:     i18n.T("Print the value of a configuration key"),
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: configKeyNames(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key, err := config.LookupKey(args[0])
+			if err != nil {
+				return err
+			}
+			c, err := config.Load()
+			if err != nil {
+				return err
+			}
+			fmt.Println(key.Get(&c.Settings))
+			return nil
+		},
+	}
+}
+
+func configSetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:       "set <key> <value>",
+		Short:     i18n.T("Set the value of a configuration key"),
+		Args:      cobra.ExactArgs(2),
+		ValidArgs: configKeyNames(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigSet(cmd.Context(), args[0], args[1])
+		},
+	}
+}
+
+func runConfigSet(ctx context.Context, name, value string) error {
+	key, err := config.LookupKey(name)
+	if err != nil {
+		return err
+	}
+	path, err := config.Path()
+	if err != nil {
+		return err
+	}
+	c, err := config.LoadFile(path)
+	if err != nil {
+		return err
+	}
+
+	// with an explicit --profile the value goes into that profile, which is
+	// created if needed
+	profile := os.Getenv("SAK_PROFILE")
+	if profile == "" {
+		if err := key.Set(&c.Settings, value); err != nil {
+			return exit.New(exit.Usage, i18n.Errorf("invalid value for %s: %w", name, err))
+		}
+		return c.Save(ctx, path)
+	}
+	settings := c.Profiles[profile]
+	if err := key.Set(&settings, value); err != nil {
+		return exit.New(exit.Usage, i18n.Errorf("invalid value for %s: %w", name, err))
+	}
+	if c.Profiles == nil {
+		c.Profiles = map[string]config.Settings{}
+	}
+	c.Profiles[profile] = settings
+	return c.Save(ctx, path)
+}
+
+func configListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: i18n.T("List all configuration keys and their values"),
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			values, err := runConfigList()
+			if err != nil {
+				return err
+			}
+			return render(cmd, values)
+		},
+	}
+}
+
+// configValues maps every known key to its current value
+type configValues map[string]string
+
+func runConfigList() (configValues, error) {
+	c, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	values := configValues{}
+	for _, key := range config.Keys {
+		values[key.Name] = key.Get(&c.Settings)
+	}
+	return values, nil
+}
+
+func (values configValues) WriteTable(w io.Writer, opts output.Options) error {
+	t := table.NewKeyValue()
+	for _, key := range config.Keys {
+		t.AddRow(key.Name, values[key.Name])
+	}
+	return t.Render(w, opts)
+}
+
+func configEditCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "edit",
+		Short: i18n.T("Open the configuration file in an editor"),
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigEdit(cmd.Context())
+		},
+	}
+}
+
+func runConfigEdit(ctx context.Context) error {
+	path, err := config.Path()
+	if err != nil {
+		return err
+	}
+	c, err := config.LoadFile(path)
+	if err != nil {
+		return err
+	}
+	// the editor may be set by the active profile
+	c, err = c.Resolve(c.ActiveProfile())
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		if err := dryrun.MkdirAll(ctx, filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		if err := dryrun.WriteFile(ctx, path, nil, 0o644); err != nil {
+			return err
+		}
+	}
+
+	editor := strings.Fields(editorCommand(c))
+	editCmd := exec.Command(editor[0], append(editor[1:], path)...)
+	editCmd.Stdin = os.Stdin
+	editCmd.Stdout = os.Stdout
+	editCmd.Stderr = os.Stderr
+	if err := dryrun.Run(ctx, editCmd); err != nil {
+		return i18n.Errorf("run editor: %w", err)
+	}
+
+	if _, err := config.LoadFile(path); err != nil {
+		return exit.New(exit.ParseError, i18n.Errorf("config is invalid after editing: %w", err))
+	}
+	return nil
+}
+
+// editorCommand returns the configured editor, falling back to $VISUAL,
+// $EDITOR and finally vi
+func editorCommand(c *config.Config) string {
+	for _, editor := range []string{c.Editor, os.Getenv("VISUAL"), os.Getenv("EDITOR")} {
+		if strings.TrimSpace(editor) != "" {
+			return editor
+		}
+	}
+	return "vi"
+}
+
+func configKeyNames() []string {
+	names := make([]string, 0, len(config.Keys))
+	for _, key := range config.Keys {
+		names = append(names, key.Name)
+	}
+	return names
+}
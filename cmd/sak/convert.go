@@ -0,0 +1,81 @@
+package main
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"github.com/hezhizhen/sak/pkg/convert"
+	"github.com/hezhizhen/sak/pkg/exit"
+	"github.com/hezhizhen/sak/pkg/i18n"
+
+	"github.com/spf13/cobra"
+)
+
+func convertCmd() *cobra.Command {
+	var from, to string
+
+	cmd := &cobra.Command{
+		Use:   "convert [file]",
+		Short: i18n.T("Convert between YAML, JSON and TOML"),
+		Long: i18n.T(`Convert between YAML, JSON and TOML
+
+The input is read from the file or stdin. Its format is taken from --from,
+or else from the extension of the file. The order of the keys is kept,
+except that TOML requires tables to follow the other keys of their parent.
+
+TOML has no null, so documents containing null cannot be converted to it.
+
+Example - convert a YAML file to JSON:
+  sak convert --to json config.yaml
+
+Example - convert TOML on stdin to YAML:
+  sak convert --from toml --to yaml < Cargo.toml
+`),
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var (
+				data []byte
+				err  error
+			)
+			if len(args) > 0 && args[0] != "-" {
+				if from == "" {
+					from = convert.FormatOf(args[0])
+				}
+				data, err = os.ReadFile(args[0])
+			} else {
+				data, err = io.ReadAll(os.Stdin)
+			}
+			if err != nil {
+				return err
+			}
+			if from == "" {
+				return exit.New(exit.Usage, i18n.Errorf("cannot tell the input format, use --from"))
+			}
+
+			from, to = strings.ToLower(from), strings.ToLower(to)
+			for _, format := range []string{from, to} {
+				if err := convert.CheckFormat(format); err != nil {
+					return err
+				}
+			}
+			docs, err := convert.Read(data, from)
+			if err != nil {
+				return err
+			}
+			return convert.Write(os.Stdout, docs, to)
+		},
+	}
+
+	cmd.Flags().StringVar(&from, "from", "", i18n.T("format of the input: yaml, json or toml"))
+	cmd.Flags().StringVar(&to, "to", "", i18n.T("format of the output: yaml, json or toml"))
+	_ = cmd.MarkFlagRequired("to")
+	_ = cmd.RegisterFlagCompletionFunc("from", completeFormats)
+	_ = cmd.RegisterFlagCompletionFunc("to", completeFormats)
+
+	return cmd
+}
+
+func completeFormats(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return convert.Formats, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,319 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/hezhizhen/sak/pkg/exit"
+	"github.com/hezhizhen/sak/pkg/i18n"
+	"github.com/hezhizhen/sak/pkg/output"
+	"github.com/hezhizhen/sak/pkg/table"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+func csvCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "csv",
+		Short: i18n.T("Work with CSV files"),
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(csvViewCmd())
+
+	return cmd
+}
+
+func csvViewCmd() *cobra.Command {
+	var (
+		columns   []string
+		header    string
+		delimiter string
+		limit     int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "view <file>",
+		Short: i18n.T("Show a CSV file as a table"),
+		Long: i18n.T(`Show a CSV file as a table
+
+The file is read from stdin if it is -. The delimiter is guessed from the
+first line unless given with --delimiter. Whether the first row is a header
+is guessed as well: it is if all of its cells are distinct, non-empty and
+not numbers. Columns holding only numbers are aligned to the right.
+
+With --output json or yaml, the rows are printed as objects keyed by the
+header.
+
+Example - show the first 20 rows:
+  sak csv view data.csv --limit 20
+
+Example - show two columns, by name and by position:
+  sak csv view data.csv --columns name,3
+
+Example - show a headerless, semicolon-separated file:
+  sak csv view export.csv --header no --delimiter ';'
+`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var (
+				data []byte
+				err  error
+			)
+			if args[0] == "-" {
+				data, err = io.ReadAll(os.Stdin)
+			} else {
+				data, err = os.ReadFile(args[0])
+			}
+			if err != nil {
+				return err
+			}
+
+			comma, err := csvDelimiter(delimiter, args[0], data)
+			if err != nil {
+				return err
+			}
+			r := csv.NewReader(bytes.NewReader(data))
+			r.Comma = comma
+			r.FieldsPerRecord = -1
+			r.LazyQuotes = true
+			records, err := r.ReadAll()
+			if err != nil {
+				return exit.New(exit.ParseError, i18n.Errorf("parse %s: %w", args[0], err))
+			}
+
+			var hasHeader bool
+			switch header {
+			case "auto":
+				hasHeader = len(records) > 0 && looksLikeHeader(records[0])
+			case "yes":
+				hasHeader = true
+			case "no":
+			default:
+				return exit.New(exit.Usage, i18n.Errorf("unknown header mode %q (expected auto, yes or no)", header))
+			}
+
+			view := newCSVView(records, hasHeader)
+			if len(columns) > 0 {
+				if err := view.selectColumns(columns); err != nil {
+					return err
+				}
+			}
+			if limit > 0 && len(view.Rows) > limit {
+				fmt.Fprintln(os.Stderr, i18n.Sprintf("showing %d of %d rows", limit, len(view.Rows)))
+				view.Rows = view.Rows[:limit]
+			}
+			return render(cmd, view)
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&columns, "columns", "c", nil, i18n.T("comma-separated names or positions of the columns to show"))
+	cmd.Flags().StringVar(&header, "header", "auto", i18n.T("whether the first row is a header: auto, yes or no"))
+	cmd.Flags().StringVarP(&delimiter, "delimiter", "d", "", i18n.T("field delimiter, guessed by default"))
+	cmd.Flags().IntVarP(&limit, "limit", "n", 0, i18n.T("maximum number of rows to show, 0 for all"))
+
+	return cmd
+}
+
+// csvDelimiters are the delimiters csvDelimiter guesses from
+const csvDelimiters = ",;\t|"
+
+// csvDelimiter returns the delimiter given by flag, or else the one of a
+// .tsv file, or else the most frequent candidate in the first line of data
+func csvDelimiter(flag, name string, data []byte) (rune, error) {
+	switch {
+	case flag == `\t`:
+		return '\t', nil
+	case flag != "":
+		if r := []rune(flag); len(r) == 1 {
+			return r[0], nil
+		}
+		return 0, exit.New(exit.Usage, i18n.Errorf("the delimiter must be a single character"))
+	case strings.EqualFold(filepath.Ext(name), ".tsv"):
+		return '\t', nil
+	}
+
+	first, _, _ := bytes.Cut(data, []byte("\n"))
+	best, count := ',', 0
+	for _, d := range csvDelimiters {
+		if n := bytes.Count(first, []byte(string(d))); n > count {
+			best, count = d, n
+		}
+	}
+	return best, nil
+}
+
+// looksLikeHeader guesses whether row is a header: its cells are distinct,
+// non-empty and not numbers
+func looksLikeHeader(row []string) bool {
+	seen := map[string]bool{}
+	for _, cell := range row {
+		cell = strings.TrimSpace(cell)
+		if cell == "" || seen[cell] || isNumber(cell) {
+			return false
+		}
+		seen[cell] = true
+	}
+	return true
+}
+
+func isNumber(s string) bool {
+	_, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	return err == nil
+}
+
+// csvView is a CSV file as shown by `sak csv view`
+type csvView struct {
+	Header []string
+	Rows   [][]string
+}
+
+// newCSVView pads the records to the same width. Columns without a header
+// are named by their position.
+func newCSVView(records [][]string, hasHeader bool) *csvView {
+	width := 0
+	for _, record := range records {
+		if len(record) > width {
+			width = len(record)
+		}
+	}
+	for i, record := range records {
+		for len(record) < width {
+			record = append(record, "")
+		}
+		records[i] = record
+	}
+
+	view := &csvView{Header: make([]string, width), Rows: [][]string{}}
+	if hasHeader && len(records) > 0 {
+		copy(view.Header, records[0])
+		records = records[1:]
+	}
+	for i, h := range view.Header {
+		if strings.TrimSpace(h) == "" {
+			view.Header[i] = strconv.Itoa(i + 1)
+		}
+	}
+	view.Rows = append(view.Rows, records...)
+	return view
+}
+
+// selectColumns keeps the columns named by their header or 1-based position
+func (v *csvView) selectColumns(names []string) error {
+	var indexes []int
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		index := -1
+		for i, h := range v.Header {
+			if strings.EqualFold(strings.TrimSpace(h), name) {
+				index = i
+				break
+			}
+		}
+		if n, err := strconv.Atoi(name); index < 0 && err == nil && n >= 1 && n <= len(v.Header) {
+			index = n - 1
+		}
+		if index < 0 {
+			return exit.New(exit.Usage, i18n.Errorf("unknown column %q", name))
+		}
+		indexes = append(indexes, index)
+	}
+
+	pick := func(row []string) []string {
+		picked := make([]string, len(indexes))
+		for i, index := range indexes {
+			picked[i] = row[index]
+		}
+		return picked
+	}
+	v.Header = pick(v.Header)
+	for i, row := range v.Rows {
+		v.Rows[i] = pick(row)
+	}
+	return nil
+}
+
+func (v *csvView) WriteTable(w io.Writer, opts output.Options) error {
+	t := table.New(v.Header...)
+	for column := range v.Header {
+		numeric, empty := true, true
+		for _, row := range v.Rows {
+			if cell := strings.TrimSpace(row[column]); cell != "" {
+				empty = false
+				numeric = numeric && isNumber(cell)
+			}
+		}
+		if numeric && !empty {
+			t.SetAlign(column, table.Right)
+		}
+	}
+	// line breaks inside quoted cells would break the alignment
+	flatten := strings.NewReplacer("\r\n", " ", "\n", " ")
+	for _, row := range v.Rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = flatten.Replace(cell)
+		}
+		t.AddRow(cells...)
+	}
+	return t.Render(w, opts)
+}
+
+// MarshalJSON writes the rows as objects keyed by the header, in the order
+// of the columns
+func (v *csvView) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i, row := range v.Rows {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteByte('{')
+		for j, cell := range row {
+			if j > 0 {
+				buf.WriteByte(',')
+			}
+			key, err := json.Marshal(v.Header[j])
+			if err != nil {
+				return nil, err
+			}
+			value, err := json.Marshal(cell)
+			if err != nil {
+				return nil, err
+			}
+			buf.Write(key)
+			buf.WriteByte(':')
+			buf.Write(value)
+		}
+		buf.WriteByte('}')
+	}
+	buf.WriteByte(']')
+	return buf.Bytes(), nil
+}
+
+// MarshalYAML writes the rows like MarshalJSON
+func (v *csvView) MarshalYAML() (interface{}, error) {
+	list := &yaml.Node{Kind: yaml.SequenceNode}
+	for _, row := range v.Rows {
+		object := &yaml.Node{Kind: yaml.MappingNode}
+		for j, cell := range row {
+			object.Content = append(object.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v.Header[j]},
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: cell},
+			)
+		}
+		list.Content = append(list.Content, object)
+	}
+	return list, nil
+}
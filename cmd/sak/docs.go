@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/hezhizhen/sak/pkg/i18n"
+	"github.com/hezhizhen/sak/pkg/version"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
+)
+
+func docsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "docs",
+		Short: i18n.T("Generate documentation for sak"),
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(docsManCmd())
+
+	return cmd
+}
+
+func docsManCmd() *cobra.Command {
+	var dir string
+
+	cmd := &cobra.Command{
+		Use:   "man",
+		Short: i18n.T("Generate man pages for every sak command"),
+		Long: i18n.T(`Generate man pages for every sak command
+
+One page is written per command, named after its full path, e.g.
+sak-version.1 for "sak version".
+
+Example - generate man pages into ./man:
+  sak docs man -o ./man
+`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDocsMan(cmd.Root(), dir)
+		},
+	}
+
+	cmd.Flags().StringVarP(&dir, "output-dir", "o", "man", i18n.T("directory to write the man pages to"))
+
+	return cmd
+}
+
+func runDocsMan(root *cobra.Command, dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	header := &doc.GenManHeader{
+		Section: "1",
+		Source:  "sak " + version.GetVersion(),
+		Manual:  "sak manual",
+	}
+	root.DisableAutoGenTag = true
+	if err := doc.GenManTree(root, header, dir); err != nil {
+		return err
+	}
+
+	fmt.Println(i18n.Sprintf("Man pages written to %s", dir))
+	return nil
+}
@@ -0,0 +1,234 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hezhizhen/sak/pkg/exit"
+	"github.com/hezhizhen/sak/pkg/habit"
+	"github.com/hezhizhen/sak/pkg/i18n"
+	"github.com/hezhizhen/sak/pkg/output"
+	"github.com/hezhizhen/sak/pkg/table"
+	"github.com/hezhizhen/sak/pkg/utils"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/spf13/cobra"
+)
+
+func habitCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "habit",
+		Short: i18n.T("Track daily habits"),
+		Long: i18n.T(`Track daily habits
+
+Habits are checked off once a day and stored in sak/habits.csv under
+$XDG_DATA_HOME (default ~/.local/share), with a column per habit and a row
+per day.
+
+Example - start tracking a habit:
+  sak habit add read
+
+Example - check it off for today:
+  sak habit check read
+
+Example - show streaks and a heatmap of the last 12 weeks:
+  sak habit stats read
+`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(habitAddCmd())
+	cmd.AddCommand(habitCheckCmd())
+	cmd.AddCommand(habitStatsCmd())
+
+	return cmd
+}
+
+func habitAddCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "add <name>",
+		Short: i18n.T("Start tracking a habit"),
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tracker, err := habit.Load()
+			if err != nil {
+				return err
+			}
+			if err := tracker.Add(args[0]); err != nil {
+				return err
+			}
+			return tracker.Save(cmd.Context())
+		},
+	}
+}
+
+func habitCheckCmd() *cobra.Command {
+	var (
+		date string
+		undo bool
+	)
+
+	cmd := &cobra.Command{
+		Use:               "check <name>",
+		Short:             i18n.T("Check off a habit for today or another day"),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeHabits,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			day, end, err := utils.ParseDateRange(date, time.Now())
+			if err != nil {
+				return err
+			}
+			if end.Sub(day) > 25*time.Hour {
+				return exit.New(exit.Usage, i18n.Errorf("--date must be a single day, got %q", date))
+			}
+			tracker, err := habit.Load()
+			if err != nil {
+				return err
+			}
+			if err := tracker.Check(args[0], day, !undo); err != nil {
+				return err
+			}
+			return tracker.Save(cmd.Context())
+		},
+	}
+
+	cmd.Flags().StringVar(&date, "date", "today", i18n.T("day to check, e.g. yesterday or 2025-07-01"))
+	cmd.Flags().BoolVar(&undo, "undo", false, i18n.T("remove the check instead"))
+
+	return cmd
+}
+
+func habitStatsCmd() *cobra.Command {
+	var weeks int
+
+	cmd := &cobra.Command{
+		Use:               "stats [name...]",
+		Short:             i18n.T("Show habit streaks and heatmaps"),
+		ValidArgsFunction: completeHabits,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tracker, err := habit.Load()
+			if err != nil {
+				return err
+			}
+			names := args
+			if len(names) == 0 {
+				names = tracker.Habits
+			}
+			today := time.Now()
+			list := habitList{tracker: tracker, today: today, weeks: weeks, Habits: []habitStats{}}
+			for _, name := range names {
+				if !tracker.Has(name) {
+					return exit.New(exit.NotFound, i18n.Errorf("unknown habit %q", name))
+				}
+				list.Habits = append(list.Habits, habitStats{
+					Name:  name,
+					Today: tracker.Done(name, today),
+					Stats: tracker.Stats(name, today),
+				})
+			}
+			return render(cmd, list)
+		},
+	}
+
+	cmd.Flags().IntVar(&weeks, "weeks", 12, i18n.T("number of weeks in the heatmap, 0 to hide it"))
+
+	return cmd
+}
+
+// completeHabits completes the names of the tracked habits
+func completeHabits(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	tracker, err := habit.Load()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return tracker.Habits, cobra.ShellCompDirectiveNoFileComp
+}
+
+type habitStats struct {
+	Name        string `json:"name" yaml:"name"`
+	Today       bool   `json:"today" yaml:"today"`
+	habit.Stats `yaml:",inline"`
+}
+
+type habitList struct {
+	Habits []habitStats `json:"habits" yaml:"habits"`
+
+	tracker *habit.Tracker
+	today   time.Time
+	weeks   int
+}
+
+func (list habitList) WriteTable(w io.Writer, opts output.Options) error {
+	t := table.New(i18n.T("HABIT"), i18n.T("TODAY"), i18n.T("STREAK"), i18n.T("BEST"), i18n.T("TOTAL"))
+	for column := 2; column < 5; column++ {
+		t.SetAlign(column, table.Right)
+	}
+	for _, h := range list.Habits {
+		today := ""
+		if h.Today {
+			today = "✓"
+		}
+		row := t.AddRow(h.Name, today, strconv.Itoa(h.Current), strconv.Itoa(h.Best), strconv.Itoa(h.Total))
+		if h.Today {
+			t.SetRowStyle(row, output.Success)
+		}
+	}
+	if err := t.Render(w, opts); err != nil {
+		return err
+	}
+
+	if list.weeks <= 0 || opts.Format == output.Markdown {
+		return nil
+	}
+	for _, h := range list.Habits {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, opts.Style(output.Heading, h.Name))
+		writeHeatmap(w, opts, list.today, list.weeks, func(day time.Time) bool {
+			return list.tracker.Done(h.Name, day)
+		})
+	}
+	return nil
+}
+
+// weekdayLabels returns the row labels of a heatmap, starting on Monday
+func weekdayLabels() []string {
+	return []string{i18n.T("Mon"), i18n.T("Tue"), i18n.T("Wed"), i18n.T("Thu"), i18n.T("Fri"), i18n.T("Sat"), i18n.T("Sun")}
+}
+
+// writeHeatmap draws a grid of the last weeks up to today with a row per
+// weekday and a column per week, marking the days for which done is true
+func writeHeatmap(w io.Writer, opts output.Options, today time.Time, weeks int, done func(day time.Time) bool) {
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, today.Location())
+	monday := today.AddDate(0, 0, -(int(today.Weekday())+6)%7)
+	first := monday.AddDate(0, 0, -7*(weeks-1))
+
+	labels := weekdayLabels()
+	labelWidth := 0
+	for _, label := range labels {
+		if width := runewidth.StringWidth(label); width > labelWidth {
+			labelWidth = width
+		}
+	}
+	for weekday, label := range labels {
+		cells := make([]string, weeks)
+		for week := range cells {
+			day := first.AddDate(0, 0, 7*week+weekday)
+			switch {
+			case day.After(today):
+				cells[week] = " "
+			case done(day):
+				cells[week] = opts.Style(output.Success, "■")
+			default:
+				cells[week] = "·"
+			}
+		}
+		line := runewidth.FillRight(label, labelWidth) + " " + strings.Join(cells, " ")
+		fmt.Fprintln(w, strings.TrimRight(line, " "))
+	}
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hezhizhen/sak/pkg/exit"
+	"github.com/hezhizhen/sak/pkg/i18n"
+	"github.com/hezhizhen/sak/pkg/ids"
+
+	"github.com/spf13/cobra"
+)
+
+// idOptions are the flags shared by the ID generators
+type idOptions struct {
+	count int
+	upper bool
+	lower bool
+}
+
+// generate prints count IDs returned by next in the requested case
+func (o *idOptions) generate(next func() (string, error)) error {
+	if o.count < 1 {
+		return exit.New(exit.Usage, i18n.Errorf("the count must be at least 1"))
+	}
+	for i := 0; i < o.count; i++ {
+		id, err := next()
+		if err != nil {
+			return err
+		}
+		switch {
+		case o.upper:
+			id = strings.ToUpper(id)
+		case o.lower:
+			id = strings.ToLower(id)
+		}
+		fmt.Println(id)
+	}
+	return nil
+}
+
+func idCmd() *cobra.Command {
+	var opts idOptions
+
+	cmd := &cobra.Command{
+		Use:   "id",
+		Short: i18n.T("Generate UUIDs, ULIDs and nano IDs"),
+		Long: i18n.T(`Generate UUIDs, ULIDs and nano IDs
+
+UUIDs are random (version 4) unless --v7 is given, which starts them with
+the time so they sort by creation. ULIDs also sort by creation and are
+written with 26 characters of Crockford's base32. Nano IDs are short random
+strings whose alphabet is URL-safe by default.
+
+Example - generate five UUIDs in uppercase:
+  sak id uuid -n 5 --upper
+
+Example - generate a UUID for use in a URL:
+  sak id uuid --url-safe
+
+Example - generate a nano ID of 10 lowercase letters and digits:
+  sak id nanoid --size 10 --lower
+`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.PersistentFlags().IntVarP(&opts.count, "count", "n", 1, i18n.T("number of IDs to generate"))
+	cmd.PersistentFlags().BoolVar(&opts.upper, "upper", false, i18n.T("print the IDs in uppercase"))
+	cmd.PersistentFlags().BoolVar(&opts.lower, "lower", false, i18n.T("print the IDs in lowercase"))
+	cmd.MarkFlagsMutuallyExclusive("upper", "lower")
+
+	cmd.AddCommand(idUUIDCmd(&opts))
+	cmd.AddCommand(idULIDCmd(&opts))
+	cmd.AddCommand(idNanoIDCmd(&opts))
+
+	return cmd
+}
+
+func idUUIDCmd(opts *idOptions) *cobra.Command {
+	var v7, urlSafe bool
+
+	cmd := &cobra.Command{
+		Use:   "uuid",
+		Short: i18n.T("Generate UUIDs"),
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if urlSafe && (opts.upper || opts.lower) {
+				return exit.New(exit.Usage, i18n.Errorf("--url-safe cannot be combined with --upper or --lower"))
+			}
+			return opts.generate(func() (string, error) {
+				u, err := ids.NewUUID()
+				if v7 {
+					u, err = ids.NewUUIDv7(time.Now())
+				}
+				if urlSafe {
+					return u.Base64(), err
+				}
+				return u.String(), err
+			})
+		},
+	}
+
+	cmd.Flags().BoolVar(&v7, "v7", false, i18n.T("generate time-ordered version 7 UUIDs"))
+	cmd.Flags().BoolVar(&urlSafe, "url-safe", false, i18n.T("print 22 characters of URL-safe base64"))
+
+	return cmd
+}
+
+func idULIDCmd(opts *idOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "ulid",
+		Short: i18n.T("Generate ULIDs"),
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return opts.generate(func() (string, error) { return ids.NewULID(time.Now()) })
+		},
+	}
+}
+
+func idNanoIDCmd(opts *idOptions) *cobra.Command {
+	var (
+		size     int
+		alphabet string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "nanoid",
+		Short: i18n.T("Generate nano IDs"),
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// changing the case afterwards would make some characters twice
+			// as likely, so a single-case alphabet is used instead
+			if !cmd.Flags().Changed("alphabet") {
+				switch {
+				case opts.upper:
+					alphabet = ids.NanoUpper
+				case opts.lower:
+					alphabet = ids.NanoLower
+				}
+			} else if opts.upper || opts.lower {
+				return exit.New(exit.Usage, i18n.Errorf("--alphabet cannot be combined with --upper or --lower"))
+			}
+			nano := idOptions{count: opts.count}
+			return nano.generate(func() (string, error) { return ids.NewNanoID(size, alphabet) })
+		},
+	}
+
+	cmd.Flags().IntVar(&size, "size", 21, i18n.T("number of characters of the IDs"))
+	cmd.Flags().StringVar(&alphabet, "alphabet", ids.NanoAlphabet, i18n.T("characters the IDs are made of"))
+
+	return cmd
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/hezhizhen/sak/pkg/config"
+	"github.com/hezhizhen/sak/pkg/exit"
+	"github.com/hezhizhen/sak/pkg/i18n"
+	"github.com/hezhizhen/sak/pkg/netinfo"
+	"github.com/hezhizhen/sak/pkg/output"
+	"github.com/hezhizhen/sak/pkg/table"
+
+	"github.com/spf13/cobra"
+)
+
+func ipCmd() *cobra.Command {
+	var all, local, public, asJSON bool
+
+	cmd := &cobra.Command{
+		Use:   "ip",
+		Short: i18n.T("Show the local and public IP addresses"),
+		Long: i18n.T(`Show the local and public IP addresses
+
+The addresses of the interfaces that are up are listed, except loopback
+ones unless --all is given. The public address is asked to the service set
+with the ip-lookup config key, https://api.ipify.org by default, which must
+answer it as plain text. If it cannot be reached, a warning is printed and
+the local addresses are still shown.
+
+Example - show the addresses as JSON:
+  sak ip --json
+
+Example - print only the public address:
+  sak ip --public
+
+Example - use another lookup service:
+  sak config set ip-lookup https://ifconfig.me/ip
+`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if asJSON {
+				if cmd.Flags().Changed("output") {
+					return exit.New(exit.Usage, i18n.Errorf("--json cannot be combined with --output"))
+				}
+				if err := cmd.Flags().Set("output", string(output.JSON)); err != nil {
+					return err
+				}
+			}
+
+			result := ipResult{}
+			if !public {
+				addresses, err := netinfo.Addresses(all)
+				if err != nil {
+					return err
+				}
+				result.Interfaces = addresses
+			}
+			if !local {
+				c, err := config.Load()
+				if err != nil {
+					return err
+				}
+				ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Second)
+				defer cancel()
+				ip, err := netinfo.PublicIP(ctx, c.IPLookupURL())
+				switch {
+				case err == nil:
+					result.Public = ip
+				case public:
+					// the public address is all that was asked for
+					return i18n.Errorf("get public IP address: %w", err)
+				default:
+					fmt.Fprintln(os.Stderr, i18n.Sprintf("warning: get public IP address: %v", err))
+				}
+			}
+
+			if public && !asJSON && !cmd.Flags().Changed("output") {
+				fmt.Println(result.Public)
+				return nil
+			}
+			return render(cmd, result)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&all, "all", "a", false, i18n.T("include loopback interfaces and interfaces that are down"))
+	cmd.Flags().BoolVar(&local, "local", false, i18n.T("only show the local addresses, without looking up the public one"))
+	cmd.Flags().BoolVar(&public, "public", false, i18n.T("only show the public address"))
+	cmd.Flags().BoolVar(&asJSON, "json", false, i18n.T("print JSON, the same as --output json"))
+	cmd.MarkFlagsMutuallyExclusive("local", "public")
+	cmd.MarkFlagsMutuallyExclusive("all", "public")
+
+	return cmd
+}
+
+type ipResult struct {
+	Interfaces []netinfo.Address `json:"interfaces,omitempty" yaml:"interfaces,omitempty"`
+	Public     string            `json:"public,omitempty" yaml:"public,omitempty"`
+}
+
+func (r ipResult) WriteTable(w io.Writer, opts output.Options) error {
+	if r.Interfaces != nil {
+		t := table.New(i18n.T("INTERFACE"), i18n.T("FAMILY"), i18n.T("ADDRESS"), i18n.T("MAC"))
+		for _, a := range r.Interfaces {
+			t.AddRow(a.Interface, a.Family, a.Address, a.MAC)
+		}
+		if err := t.Render(w, opts); err != nil {
+			return err
+		}
+	}
+	if r.Public != "" {
+		if r.Interfaces != nil {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintln(w, i18n.T("Public:"), opts.Style(output.Accent, r.Public))
+	}
+	return nil
+}
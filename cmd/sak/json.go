@@ -0,0 +1,174 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/hezhizhen/sak/pkg/exit"
+	"github.com/hezhizhen/sak/pkg/i18n"
+	"github.com/hezhizhen/sak/pkg/jsonpath"
+
+	"github.com/spf13/cobra"
+)
+
+func jsonCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "json",
+		Short: i18n.T("Format and query JSON"),
+		Long: i18n.T(`Format and query JSON
+
+JSON is read from the file given as the last argument or from stdin. Several
+documents in a row, such as JSON lines, are handled one by one. The order
+of object keys is kept.
+
+Paths are dotted keys with array indexes in brackets or as numbers. Keys
+containing dots are quoted in brackets. Negative indexes count from the end.
+
+Example - pretty-print an API response:
+  curl -s https://api.github.com/repos/spf13/cobra | sak json fmt
+
+Example - print the name of the first item:
+  sak json get '.items[0].name' response.json
+
+Example - read a key containing dots:
+  sak json get '.annotations["app.kubernetes.io/name"]' -r < pod.json
+`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(jsonFmtCmd())
+	cmd.AddCommand(jsonGetCmd())
+
+	return cmd
+}
+
+func jsonFmtCmd() *cobra.Command {
+	var (
+		minify bool
+		indent int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "fmt [file]",
+		Short: i18n.T("Pretty-print or minify JSON"),
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if indent < 0 {
+				return exit.New(exit.Usage, i18n.Errorf("the indent must be at least 0"))
+			}
+			docs, err := readJSON(args)
+			if err != nil {
+				return err
+			}
+			for _, doc := range docs {
+				if err := writeJSON(doc, minify, indent); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&minify, "minify", "m", false, i18n.T("remove all insignificant whitespace"))
+	cmd.Flags().IntVar(&indent, "indent", 2, i18n.T("number of spaces to indent with"))
+
+	return cmd
+}
+
+func jsonGetCmd() *cobra.Command {
+	var (
+		raw    bool
+		minify bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "get <path> [file]",
+		Short: i18n.T("Print the value at a path"),
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := jsonpath.Parse(args[0])
+			if err != nil {
+				return err
+			}
+			docs, err := readJSON(args[1:])
+			if err != nil {
+				return err
+			}
+			for _, doc := range docs {
+				value, err := jsonpath.Get(doc, path)
+				if err != nil {
+					return err
+				}
+				var s string
+				if raw && json.Unmarshal(value, &s) == nil {
+					if _, err := io.WriteString(os.Stdout, s+"\n"); err != nil {
+						return err
+					}
+					continue
+				}
+				if err := writeJSON(value, minify, 2); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&raw, "raw", "r", false, i18n.T("print strings without quotes"))
+	cmd.Flags().BoolVarP(&minify, "minify", "m", false, i18n.T("print objects and arrays on one line"))
+
+	return cmd
+}
+
+// readJSON reads the JSON documents of the file in args, or of stdin
+func readJSON(args []string) ([]json.RawMessage, error) {
+	name, r := "stdin", io.Reader(os.Stdin)
+	if len(args) > 0 && args[0] != "-" {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		name, r = args[0], f
+	}
+
+	docs := []json.RawMessage{}
+	dec := json.NewDecoder(r)
+	for {
+		var doc json.RawMessage
+		err := dec.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, exit.New(exit.ParseError, i18n.Errorf("parse %s: %w", name, err))
+		}
+		docs = append(docs, doc)
+	}
+	return docs, nil
+}
+
+// writeJSON prints doc indented by indent spaces, or on one line if minify
+// is set
+func writeJSON(doc json.RawMessage, minify bool, indent int) error {
+	var buf bytes.Buffer
+	var err error
+	if minify {
+		err = json.Compact(&buf, doc)
+	} else {
+		err = json.Indent(&buf, doc, "", strings.Repeat(" ", indent))
+	}
+	if err != nil {
+		return err
+	}
+	buf.WriteByte('\n')
+	_, err = buf.WriteTo(os.Stdout)
+	return err
+}
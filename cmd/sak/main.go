@@ -1,13 +1,73 @@
 package main
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/hezhizhen/sak/pkg/config"
+	"github.com/hezhizhen/sak/pkg/dryrun"
+	"github.com/hezhizhen/sak/pkg/exit"
+	"github.com/hezhizhen/sak/pkg/i18n"
+	"github.com/hezhizhen/sak/pkg/output"
+	"github.com/hezhizhen/sak/pkg/utils"
+
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 func main() {
+	applyLanguage()
+	cmd := rootCmd()
+
+	if ok, code := dispatchPlugin(cmd, os.Args[1:]); ok {
+		recordHistory(os.Args[1:], code)
+		os.Exit(code)
+	}
+
+	code := execute(cmd)
+	notifyUpdate(cmd, os.Args[1:])
+	recordHistory(os.Args[1:], code)
+	os.Exit(code)
+}
+
+func rootCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "sak",
-		Short: "My tool set",
+		Short: i18n.T("My tool set"),
+		Long: i18n.T(`My tool set
+
+Exit codes:
+  0  success
+  1  failure
+  2  invalid usage
+  3  not found
+  4  parse error
+  5  external tool missing
+  6  aborted by the user
+`),
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			// exported so config loading, plugins and re-runs all see it
+			if profile, _ := cmd.Flags().GetString("profile"); profile != "" {
+				// config set creates the profile it is given
+				if cmd.CommandPath() != "sak config set" {
+					if err := checkProfile(profile); err != nil {
+						return err
+					}
+				}
+				os.Setenv("SAK_PROFILE", profile)
+				applyLanguage()
+			}
+			if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+				cmd.SetContext(dryrun.WithDryRun(cmd.Context(), os.Stderr))
+			}
+			yes, _ := cmd.Flags().GetBool("yes")
+			quiet, _ := cmd.Flags().GetBool("quiet")
+			cmd.SetContext(utils.WithPromptOptions(cmd.Context(), utils.PromptOptions{Yes: yes, Quiet: quiet}))
+			return nil
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return cmd.Help()
 		},
@@ -15,10 +75,195 @@ func main() {
 		SilenceErrors: true,
 	}
 
+	cmd.SetUsageTemplate(translateUsage(cmd.UsageTemplate()))
+
+	cmd.PersistentFlags().String("output", string(output.Table), i18n.T("output format: table, json, yaml or markdown"))
+	cmd.PersistentFlags().Bool("no-color", false, i18n.T("disable colored output"))
+	cmd.PersistentFlags().Bool("dry-run", false, i18n.T("only print what would be changed"))
+	cmd.PersistentFlags().String("profile", "", i18n.T("configuration profile to use"))
+	cmd.PersistentFlags().String("error-format", "text", i18n.T("error output format: text or json"))
+	cmd.PersistentFlags().BoolP("yes", "y", false, i18n.T("answer yes to every confirmation"))
+	cmd.PersistentFlags().BoolP("quiet", "q", false, i18n.T("never prompt; take the default answers"))
+
 	cmd.AddCommand(versionCmd())
+	cmd.AddCommand(selfUpdateCmd())
+	cmd.AddCommand(docsCmd())
+	cmd.AddCommand(configCmd())
+	cmd.AddCommand(pluginCmd())
+	cmd.AddCommand(recentCmd())
+	cmd.AddCommand(shellCmd())
+	cmd.AddCommand(profileCmd())
+	cmd.AddCommand(pomodoroCmd())
+	cmd.AddCommand(timerCmd())
+	cmd.AddCommand(stopwatchCmd())
+	cmd.AddCommand(habitCmd())
+	cmd.AddCommand(noteCmd())
+	cmd.AddCommand(bookmarkCmd())
+	cmd.AddCommand(snippetCmd())
+	cmd.AddCommand(clipCmd())
+	cmd.AddCommand(passgenCmd())
+	cmd.AddCommand(idCmd())
+	cmd.AddCommand(encodeCmd())
+	cmd.AddCommand(jsonCmd())
+	cmd.AddCommand(convertCmd())
+	cmd.AddCommand(csvCmd())
+	cmd.AddCommand(tsCmd())
+	cmd.AddCommand(calCmd())
+	cmd.AddCommand(weatherCmd())
+	cmd.AddCommand(ipCmd())
+	cmd.AddCommand(portCmd())
+
+	return cmd
+}
+
+// execute runs root and returns the code sak should exit with, reporting any
+// error on stderr. Errors raised before the command starts running (unknown
+// commands, bad or missing flags, bad arguments) are usage errors.
+func execute(root *cobra.Command) int {
+	started := false
+	preRun := root.PersistentPreRunE
+	root.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		// cobra checks these after the pre-run, but they are usage errors too
+		if err := cmd.ValidateRequiredFlags(); err != nil {
+			return err
+		}
+		if err := cmd.ValidateFlagGroups(); err != nil {
+			return err
+		}
+		if format, _ := cmd.Flags().GetString("error-format"); format != "text" && format != "json" {
+			return exit.New(exit.Usage, i18n.Errorf("unknown error format %q (expected text or json)", format))
+		}
+		started = true
+		return preRun(cmd, args)
+	}
+
+	err := root.Execute()
+	if err == nil {
+		return exit.OK
+	}
+	if !started {
+		err = exit.New(exit.Usage, err)
+	}
+	code := exit.Code(err)
+
+	if format, _ := root.PersistentFlags().GetString("error-format"); format == "json" {
+		_ = json.NewEncoder(os.Stderr).Encode(struct {
+			Error string `json:"error"`
+			Kind  string `json:"kind"`
+			Code  int    `json:"code"`
+		}{err.Error(), exit.Kind(code), code})
+	} else {
+		fmt.Fprintln(os.Stderr, i18n.T("Error:"), err)
+	}
+	return code
+}
+
+// globalFlags returns a flag set of the global flags of root that stops
+// parsing at the first argument that is not a flag, the name of a command
+func globalFlags(root *cobra.Command) *pflag.FlagSet {
+	flags := pflag.NewFlagSet(root.Name(), pflag.ContinueOnError)
+	flags.AddFlagSet(root.PersistentFlags())
+	flags.SetInterspersed(false)
+	flags.SetOutput(io.Discard)
+	return flags
+}
+
+// commandName returns the name of the top-level command run by args, e.g.
+// "recent" for "--profile work recent list". Commands cobra only adds while
+// running, such as __complete, and plugins are named as given. It returns
+// "" if args name no command or their global flags are invalid.
+func commandName(args []string) string {
+	root := rootCmd()
+	flags := globalFlags(root)
+	if err := flags.Parse(args); err != nil || flags.NArg() == 0 {
+		return ""
+	}
+	root.InitDefaultHelpCmd()
+	root.InitDefaultCompletionCmd()
+	cmd, _, err := root.Find(flags.Args())
+	if err != nil || cmd == root {
+		return flags.Arg(0)
+	}
+	for cmd.Parent() != root {
+		cmd = cmd.Parent()
+	}
+	return cmd.Name()
+}
+
+// checkProfile returns an error if profile is not defined in the config file
+func checkProfile(profile string) error {
+	path, err := config.Path()
+	if err != nil {
+		return err
+	}
+	c, err := config.LoadFile(path)
+	if err != nil {
+		return err
+	}
+	_, err = c.Resolve(profile)
+	return err
+}
+
+// applyLanguage selects the output language from the config. Help texts are
+// translated when the commands are built, so a profile selected with
+// --profile only changes the language of the command output.
+func applyLanguage() {
+	c, err := config.Load()
+	if err != nil {
+		return
+	}
+	if language, err := i18n.ParseLanguage(c.Language); err == nil {
+		i18n.SetLanguage(language)
+	}
+}
 
-	err := cmd.Execute()
+// translateUsage translates the section headings of a cobra usage template
+func translateUsage(tmpl string) string {
+	for _, heading := range []string{
+		"Usage:", "Aliases:", "Examples:", "Available Commands:", "Additional Commands:",
+		"Global Flags:", "Flags:", "Additional help topics:",
+	} {
+		tmpl = strings.Replace(tmpl, "\n"+heading, "\n"+i18n.T(heading), 1)
+		if strings.HasPrefix(tmpl, heading) {
+			tmpl = i18n.T(heading) + strings.TrimPrefix(tmpl, heading)
+		}
+	}
+	return strings.Replace(tmpl,
+		`Use "{{.CommandPath}} [command] --help" for more information about a command.`,
+		i18n.T(`Use "{{.CommandPath}} [command] --help" for more information about a command.`), 1)
+}
+
+// render writes v to stdout as selected by the global output flags
+func render(cmd *cobra.Command, v interface{}) error {
+	opts, err := outputOptions(cmd)
+	if err != nil {
+		return err
+	}
+	return output.Render(os.Stdout, opts, v)
+}
+
+// outputOptions resolves the global --output and --no-color flags, falling
+// back to the color config key and terminal detection, and the table-border
+// config key
+func outputOptions(cmd *cobra.Command) (output.Options, error) {
+	value, err := cmd.Flags().GetString("output")
 	if err != nil {
-		panic(err)
+		return output.Options{}, err
+	}
+	format, err := output.ParseFormat(value)
+	if err != nil {
+		return output.Options{}, err
+	}
+	opts := output.Options{Format: format}
+
+	c, err := config.Load()
+	if err != nil {
+		return output.Options{}, err
+	}
+	opts.Border = c.TableBorderEnabled()
+	opts.Theme = c.OutputTheme()
+	if noColor, _ := cmd.Flags().GetBool("no-color"); !noColor {
+		opts.Color = output.ColorEnabled(c.Color, os.Stdout)
 	}
+	return opts, nil
 }
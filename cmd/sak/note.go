@@ -0,0 +1,184 @@
+package main
+
+import (
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hezhizhen/sak/pkg/exit"
+	"github.com/hezhizhen/sak/pkg/i18n"
+	"github.com/hezhizhen/sak/pkg/note"
+	"github.com/hezhizhen/sak/pkg/output"
+	"github.com/hezhizhen/sak/pkg/table"
+	"github.com/hezhizhen/sak/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func noteCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "note <text...>",
+		Short: i18n.T("Capture quick notes in an inbox"),
+		Long: i18n.T(`Capture quick notes in an inbox
+
+Notes are appended as timestamped list items to sak/inbox.md under
+$XDG_DATA_HOME (default ~/.local/share), a plain Markdown file that can be
+edited by hand. Use -- before a note starting with list or grep.
+
+Example - capture an idea:
+  sak note idea about X
+
+Example - list this week's notes:
+  sak note list --range this-week
+
+Example - find notes mentioning docker:
+  sak note grep docker
+`),
+		Args: cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return cmd.Help()
+			}
+			return note.Append(cmd.Context(), note.Note{Time: time.Now(), Text: strings.Join(args, " ")})
+		},
+	}
+
+	cmd.AddCommand(noteListCmd())
+	cmd.AddCommand(noteGrepCmd())
+
+	return cmd
+}
+
+func noteListCmd() *cobra.Command {
+	var (
+		limit int
+		span  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: i18n.T("List the latest notes"),
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			notes, err := note.Load()
+			if err != nil {
+				return err
+			}
+			list := noteEntries(notes)
+			if span != "" {
+				start, end, err := utils.ParseDateRange(span, time.Now())
+				if err != nil {
+					return err
+				}
+				list = list.filter(func(n note.Note) bool {
+					return !n.Time.Before(start) && n.Time.Before(end)
+				})
+			}
+			return render(cmd, list.last(limit))
+		},
+	}
+
+	cmd.Flags().IntVarP(&limit, "limit", "n", 20, i18n.T("number of notes to list, 0 for all"))
+	cmd.Flags().StringVar(&span, "range", "", i18n.T("only list notes in a date range, e.g. today or last-week"))
+
+	return cmd
+}
+
+func noteGrepCmd() *cobra.Command {
+	var ignoreCase bool
+
+	cmd := &cobra.Command{
+		Use:   "grep <pattern>",
+		Short: i18n.T("Search notes with a regular expression"),
+		Long: i18n.T(`Search notes with a regular expression
+
+The search ignores case unless the pattern contains an upper-case letter.
+
+Example - find notes about the release:
+  sak note grep 'release|deploy'
+`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pattern := args[0]
+			if ignoreCase || strings.ToLower(pattern) == pattern {
+				pattern = "(?i)" + pattern
+			}
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return exit.New(exit.Usage, i18n.Errorf("invalid pattern: %w", err))
+			}
+			notes, err := note.Load()
+			if err != nil {
+				return err
+			}
+			list := noteEntries(notes).filter(func(n note.Note) bool {
+				return re.MatchString(n.Text)
+			})
+			list.match = re
+			if len(list.Notes) == 0 {
+				return exit.New(exit.NotFound, i18n.Errorf("no notes match %q", args[0]))
+			}
+			return render(cmd, list)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&ignoreCase, "ignore-case", "i", false, i18n.T("always ignore case"))
+
+	return cmd
+}
+
+type noteEntry struct {
+	ID        int `json:"id" yaml:"id"`
+	note.Note `yaml:",inline"`
+}
+
+type noteList struct {
+	Notes []noteEntry `json:"notes" yaml:"notes"`
+
+	// match is highlighted in table output
+	match *regexp.Regexp
+}
+
+func noteEntries(notes []note.Note) noteList {
+	list := noteList{Notes: []noteEntry{}}
+	for i, n := range notes {
+		list.Notes = append(list.Notes, noteEntry{ID: i + 1, Note: n})
+	}
+	return list
+}
+
+// filter keeps the notes for which keep returns true
+func (list noteList) filter(keep func(note.Note) bool) noteList {
+	kept := noteList{Notes: []noteEntry{}, match: list.match}
+	for _, e := range list.Notes {
+		if keep(e.Note) {
+			kept.Notes = append(kept.Notes, e)
+		}
+	}
+	return kept
+}
+
+// last keeps the last limit notes, or all of them if limit is not positive
+func (list noteList) last(limit int) noteList {
+	if limit > 0 && len(list.Notes) > limit {
+		list.Notes = list.Notes[len(list.Notes)-limit:]
+	}
+	return list
+}
+
+func (list noteList) WriteTable(w io.Writer, opts output.Options) error {
+	t := table.New(i18n.T("ID"), i18n.T("TIME"), i18n.T("NOTE"))
+	t.SetAlign(0, table.Right)
+	for _, e := range list.Notes {
+		text := e.Text
+		if list.match != nil {
+			text = list.match.ReplaceAllStringFunc(text, func(s string) string {
+				return opts.Style(output.Accent, s)
+			})
+		}
+		t.AddRow(strconv.Itoa(e.ID), e.Time.Format("2006-01-02 15:04"), text)
+	}
+	return t.Render(w, opts)
+}
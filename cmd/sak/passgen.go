@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/hezhizhen/sak/pkg/clipboard"
+	"github.com/hezhizhen/sak/pkg/i18n"
+	"github.com/hezhizhen/sak/pkg/passgen"
+
+	"github.com/spf13/cobra"
+)
+
+func passgenCmd() *cobra.Command {
+	var (
+		length      int
+		symbols     bool
+		words       int
+		separator   string
+		toClipboard bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "passgen",
+		Short: i18n.T("Generate a password or passphrase"),
+		Long: i18n.T(`Generate a password or passphrase
+
+Passwords mix lowercase and uppercase letters and digits, plus symbols with
+--symbols, and contain at least one character of each. With --words a
+passphrase of random words is generated instead, as with diceware. The
+randomness comes from the operating system.
+
+The entropy of the result is printed to stderr, so only the password is
+written to stdout.
+
+Example - generate a 24 character password with symbols:
+  sak passgen --length 24 --symbols
+
+Example - copy a passphrase of six words to the clipboard:
+  sak passgen --words 6 --copy
+`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var (
+				secret string
+				bits   float64
+				err    error
+			)
+			if cmd.Flags().Changed("words") {
+				secret, bits, err = passgen.Passphrase(words, separator)
+			} else {
+				classes := []string{passgen.Lower, passgen.Upper, passgen.Digits}
+				if symbols {
+					classes = append(classes, passgen.Symbols)
+				}
+				secret, bits, err = passgen.Password(length, classes...)
+			}
+			if err != nil {
+				return err
+			}
+
+			if toClipboard {
+				if err := clipboard.Copy(secret); err != nil {
+					return err
+				}
+				fmt.Fprintln(os.Stderr, i18n.T("Copied to the clipboard"))
+			} else {
+				fmt.Println(secret)
+			}
+			fmt.Fprintln(os.Stderr, i18n.Sprintf("Entropy: %.0f bits (%s)", bits, passgen.Strength(bits)))
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVarP(&length, "length", "l", 20, i18n.T("number of characters of the password"))
+	cmd.Flags().BoolVarP(&symbols, "symbols", "s", false, i18n.T("include symbols in the password"))
+	cmd.Flags().IntVarP(&words, "words", "w", 0, i18n.T("generate a passphrase of this many words instead"))
+	cmd.Flags().StringVar(&separator, "separator", "-", i18n.T("separator between the words of a passphrase"))
+	cmd.Flags().BoolVarP(&toClipboard, "copy", "c", false, i18n.T("copy to the clipboard instead of printing"))
+	cmd.MarkFlagsMutuallyExclusive("words", "length")
+	cmd.MarkFlagsMutuallyExclusive("words", "symbols")
+
+	return cmd
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+
+	"github.com/hezhizhen/sak/pkg/config"
+	"github.com/hezhizhen/sak/pkg/exit"
+	"github.com/hezhizhen/sak/pkg/i18n"
+	"github.com/hezhizhen/sak/pkg/output"
+	"github.com/hezhizhen/sak/pkg/plugin"
+	"github.com/hezhizhen/sak/pkg/table"
+
+	"github.com/spf13/cobra"
+)
+
+func pluginCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "plugin",
+		Short: i18n.T("Manage external sak plugins"),
+		Long: i18n.T(`Manage external sak plugins
+
+Any executable named sak-<name> on $PATH can be run as "sak <name>".
+Arguments are passed through unchanged. Global flags given before the name
+are not: SAK_CONFIG and SAK_OUTPUT (the value of --output) are set in its
+environment, and SAK_PROFILE as well when --profile is given.
+
+Example - list the plugins found on $PATH:
+  sak plugin list
+`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(pluginListCmd())
+
+	return cmd
+}
+
+func pluginListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: i18n.T("List the plugins found on $PATH"),
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return render(cmd, pluginList(plugin.List()))
+		},
+	}
+}
+
+type pluginList []plugin.Plugin
+
+func (plugins pluginList) WriteTable(w io.Writer, opts output.Options) error {
+	if len(plugins) == 0 {
+		_, err := fmt.Fprintln(w, i18n.T("No plugins found on $PATH"))
+		return err
+	}
+	t := table.New(i18n.T("NAME"), i18n.T("PATH"))
+	for _, p := range plugins {
+		t.AddRow(p.Name, p.Path)
+	}
+	return t.Render(w, opts)
+}
+
+// dispatchPlugin runs the plugin named by the first argument following the
+// global flags if it is not a builtin command. It reports whether a plugin
+// was run and its exit code.
+func dispatchPlugin(root *cobra.Command, args []string) (bool, int) {
+	// the global flags are parsed here as cobra only parses the flags of
+	// the commands it knows
+	flags := globalFlags(root)
+	if err := flags.Parse(args); err != nil {
+		return false, 0
+	}
+	rest := flags.Args()
+	if len(rest) == 0 {
+		return false, 0
+	}
+	root.InitDefaultHelpCmd()
+	root.InitDefaultCompletionCmd()
+	if found, _, err := root.Find(rest); err == nil && found != root {
+		return false, 0
+	}
+	path, err := plugin.Find(rest[0])
+	if err != nil {
+		return false, 0
+	}
+
+	env := []string{"SAK_OUTPUT=" + flags.Lookup("output").Value.String()}
+	if profile := flags.Lookup("profile").Value.String(); profile != "" {
+		if err := checkProfile(profile); err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T("Error:"), err)
+			return true, exit.Code(err)
+		}
+		env = append(env, "SAK_PROFILE="+profile)
+	}
+	if configPath, err := config.Path(); err == nil {
+		env = append(env, "SAK_CONFIG="+configPath)
+	}
+	err = plugin.Run(path, rest[1:], env)
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return true, exitErr.ExitCode()
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, i18n.Sprintf("run plugin %s: %v", rest[0], err))
+		return true, 1
+	}
+	return true, 0
+}
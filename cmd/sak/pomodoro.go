@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/hezhizhen/sak/pkg/exit"
+	"github.com/hezhizhen/sak/pkg/i18n"
+	"github.com/hezhizhen/sak/pkg/notify"
+	"github.com/hezhizhen/sak/pkg/timer"
+	"github.com/hezhizhen/sak/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+type pomodoroOptions struct {
+	work      time.Duration
+	rest      time.Duration
+	longRest  time.Duration
+	longEvery int
+	rounds    int
+	notify    bool
+}
+
+func pomodoroCmd() *cobra.Command {
+	var opts pomodoroOptions
+
+	cmd := &cobra.Command{
+		Use:   "pomodoro",
+		Short: i18n.T("Run pomodoro work and break timers"),
+		Long: i18n.T(`Run pomodoro work and break timers
+
+Work sessions and breaks alternate, with a longer break after every few
+sessions. The time left is shown on a live line in the terminal and a
+desktop notification is sent at every transition (notify-send on Linux,
+osascript on macOS). Press Ctrl-C to stop.
+
+Example - four classic pomodoros:
+  sak pomodoro
+
+Example - longer sessions until stopped:
+  sak pomodoro --work 50m --break 10m --rounds 0
+`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.work <= 0 || opts.rest < 0 || opts.longRest < 0 || opts.rounds < 0 {
+				return exit.New(exit.Usage, i18n.Errorf("durations and rounds must not be negative, and --work must be positive"))
+			}
+			outputOpts, err := outputOptions(cmd)
+			if err != nil {
+				return err
+			}
+			return runPomodoro(cmd.Context(), timer.NewDisplay(os.Stdout, outputOpts), opts)
+		},
+	}
+
+	cmd.Flags().DurationVar(&opts.work, "work", 25*time.Minute, i18n.T("length of a work session"))
+	cmd.Flags().DurationVar(&opts.rest, "break", 5*time.Minute, i18n.T("length of a short break"))
+	cmd.Flags().DurationVar(&opts.longRest, "long-break", 15*time.Minute, i18n.T("length of the long break"))
+	cmd.Flags().IntVar(&opts.longEvery, "long-every", 4, i18n.T("take the long break after this many work sessions (0 for never)"))
+	cmd.Flags().IntVar(&opts.rounds, "rounds", 4, i18n.T("number of work sessions, 0 to run until stopped"))
+	cmd.Flags().BoolVar(&opts.notify, "notify", true, i18n.T("send desktop notifications"))
+
+	return cmd
+}
+
+func runPomodoro(ctx context.Context, display *timer.Display, opts pomodoroOptions) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	notifier := desktopNotifier(opts.notify)
+	done := 0
+	for round := 1; opts.rounds == 0 || round <= opts.rounds; round++ {
+		label := i18n.Sprintf("Work %d", round)
+		if opts.rounds > 0 {
+			label = i18n.Sprintf("Work %d/%d", round, opts.rounds)
+		}
+		if err := display.Countdown(ctx, label, opts.work); err != nil {
+			return pomodoroStopped(done, opts.work, err)
+		}
+		done++
+		if opts.rounds > 0 && round == opts.rounds {
+			notifier(i18n.T("Pomodoro finished"), i18n.Sprintf("%d sessions done", done))
+			break
+		}
+
+		rest, restLabel := opts.rest, i18n.T("Break")
+		if opts.longEvery > 0 && round%opts.longEvery == 0 {
+			rest, restLabel = opts.longRest, i18n.T("Long break")
+		}
+		if rest == 0 {
+			continue
+		}
+		notifier(i18n.T("Time for a break"), restLabel+" · "+utils.FormatDuration(rest))
+		if err := display.Countdown(ctx, restLabel, rest); err != nil {
+			return pomodoroStopped(done, opts.work, err)
+		}
+		notifier(i18n.T("Back to work"), i18n.Sprintf("Work %d starts now", round+1))
+	}
+
+	fmt.Println(pomodoroSummary(done, opts.work))
+	return nil
+}
+
+// pomodoroStopped reports the completed sessions when the countdown was
+// interrupted
+func pomodoroStopped(done int, work time.Duration, err error) error {
+	if !errors.Is(err, context.Canceled) {
+		return err
+	}
+	fmt.Println(pomodoroSummary(done, work))
+	return exit.New(exit.Aborted, i18n.Errorf("pomodoro stopped"))
+}
+
+func pomodoroSummary(done int, work time.Duration) string {
+	return i18n.Sprintf("Completed %d work sessions (%s)", done, utils.FormatDuration(time.Duration(done)*work))
+}
+
+// desktopNotifier returns a function sending desktop notifications, which
+// warns once and then stays silent if notifications don't work
+func desktopNotifier(enabled bool) func(title, message string) {
+	failed := !enabled
+	return func(title, message string) {
+		if failed {
+			return
+		}
+		if err := notify.Send(title, message); err != nil {
+			fmt.Fprintln(os.Stderr, i18n.Sprintf("warning: desktop notifications disabled: %v", err))
+			failed = true
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hezhizhen/sak/pkg/exit"
+	"github.com/hezhizhen/sak/pkg/i18n"
+	"github.com/hezhizhen/sak/pkg/netinfo"
+	"github.com/hezhizhen/sak/pkg/output"
+	"github.com/hezhizhen/sak/pkg/table"
+
+	"github.com/spf13/cobra"
+)
+
+func portCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "port",
+		Short: i18n.T("Check and list TCP ports"),
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(portCheckCmd())
+	cmd.AddCommand(portLsCmd())
+
+	return cmd
+}
+
+func portCheckCmd() *cobra.Command {
+	var timeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "check <host:port>...",
+		Short: i18n.T("Check whether TCP ports accept connections"),
+		Long: i18n.T(`Check whether TCP ports accept connections
+
+Each address is checked by opening a TCP connection to it, which is closed
+right away. A bare port is checked on localhost. sak exits with an error if
+any of the ports does not accept the connection within --timeout.
+
+Example - check a web server:
+  sak port check example.com:443
+
+Example - check local ports with a shorter timeout:
+  sak port check 5432 6379 --timeout 500ms
+`),
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if timeout <= 0 {
+				return exit.New(exit.Usage, i18n.Errorf("--timeout must be positive"))
+			}
+			addresses := make([]string, len(args))
+			for i, arg := range args {
+				address, err := portAddress(arg)
+				if err != nil {
+					return err
+				}
+				addresses[i] = address
+			}
+
+			results := make(portChecks, len(addresses))
+			var wg sync.WaitGroup
+			for i, address := range addresses {
+				wg.Add(1)
+				go func(i int, address string) {
+					defer wg.Done()
+					start := time.Now()
+					conn, err := net.DialTimeout("tcp", address, timeout)
+					results[i] = portCheck{Address: address, Open: err == nil}
+					if err != nil {
+						// without the "dial tcp <address>:" prefix
+						var opErr *net.OpError
+						if errors.As(err, &opErr) {
+							err = opErr.Err
+						}
+						results[i].Error = err.Error()
+						return
+					}
+					results[i].Millis = time.Since(start).Milliseconds()
+					conn.Close()
+				}(i, address)
+			}
+			wg.Wait()
+
+			if err := render(cmd, results); err != nil {
+				return err
+			}
+			closed := 0
+			for _, r := range results {
+				if !r.Open {
+					closed++
+				}
+			}
+			if closed > 0 {
+				return i18n.Errorf("%d of %d ports are not open", closed, len(results))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().DurationVar(&timeout, "timeout", 3*time.Second, i18n.T("how long to wait for each connection"))
+
+	return cmd
+}
+
+// portAddress validates a host:port address, a bare port meaning localhost
+func portAddress(s string) (string, error) {
+	if _, err := strconv.Atoi(s); err == nil {
+		s = net.JoinHostPort("localhost", s)
+	}
+	host, port, err := net.SplitHostPort(s)
+	if err != nil || port == "" {
+		return "", exit.New(exit.Usage, i18n.Errorf("invalid address %q (expected host:port)", s))
+	}
+	if host == "" {
+		host = "localhost"
+	}
+	return net.JoinHostPort(host, port), nil
+}
+
+type portCheck struct {
+	Address string `json:"address" yaml:"address"`
+	Open    bool   `json:"open" yaml:"open"`
+	// Millis is how long the connection took to open
+	Millis int64  `json:"ms,omitempty" yaml:"ms,omitempty"`
+	Error  string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+type portChecks []portCheck
+
+func (c portChecks) WriteTable(w io.Writer, opts output.Options) error {
+	t := table.New(i18n.T("ADDRESS"), i18n.T("STATUS"), i18n.T("DETAIL"))
+	for _, r := range c {
+		if !r.Open {
+			row := t.AddRow(r.Address, i18n.T("closed"), r.Error)
+			t.SetRowStyle(row, output.Error)
+			continue
+		}
+		row := t.AddRow(r.Address, i18n.T("open"), strconv.FormatInt(r.Millis, 10)+" ms")
+		t.SetRowStyle(row, output.Success)
+	}
+	return t.Render(w, opts)
+}
+
+func portLsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ls",
+		Short: i18n.T("List the listening TCP ports"),
+		Long: i18n.T(`List the listening TCP ports
+
+The ports are read from /proc, so this is only supported on Linux. The
+process owning a port is only shown if it may be inspected, which usually
+means it runs as the current user; run with sudo to see all of them.
+
+Example - list the listening ports:
+  sak port ls
+`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			listeners, err := netinfo.Listeners()
+			if err != nil {
+				return err
+			}
+			return render(cmd, portListeners(listeners))
+		},
+	}
+
+	return cmd
+}
+
+type portListeners []netinfo.Listener
+
+func (l portListeners) WriteTable(w io.Writer, opts output.Options) error {
+	t := table.New(i18n.T("PROTO"), i18n.T("ADDRESS"), i18n.T("PORT"), i18n.T("PID"), i18n.T("PROCESS"))
+	t.SetAlign(2, table.Right)
+	t.SetAlign(3, table.Right)
+	for _, listener := range l {
+		pid := ""
+		if listener.PID != 0 {
+			pid = strconv.Itoa(listener.PID)
+		}
+		t.AddRow(listener.Protocol, listener.Address, strconv.Itoa(listener.Port), pid, listener.Process)
+	}
+	return t.Render(w, opts)
+}
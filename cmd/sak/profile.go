@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/hezhizhen/sak/pkg/config"
+	"github.com/hezhizhen/sak/pkg/exit"
+	"github.com/hezhizhen/sak/pkg/i18n"
+	"github.com/hezhizhen/sak/pkg/output"
+	"github.com/hezhizhen/sak/pkg/table"
+
+	"github.com/spf13/cobra"
+)
+
+func profileCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "profile",
+		Short: i18n.T("Manage configuration profiles"),
+		Long: i18n.T(`Manage configuration profiles
+
+A profile is a named set of settings under the profiles key of the config
+file, overriding the top-level settings while it is active. The active
+profile is the one given with the global --profile flag, or else the one
+selected with "sak profile use".
+
+Example - create a work profile with its own editor:
+  sak --profile work config set editor "code --wait"
+
+Example - make the work profile the default:
+  sak profile use work
+`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(profileListCmd())
+	cmd.AddCommand(profileUseCmd())
+
+	return cmd
+}
+
+func profileListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: i18n.T("List the configured profiles"),
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := config.Path()
+			if err != nil {
+				return err
+			}
+			c, err := config.LoadFile(path)
+			if err != nil {
+				return err
+			}
+
+			active := c.ActiveProfile()
+			profiles := profileList{}
+			for name := range c.Profiles {
+				profiles = append(profiles, profileEntry{Name: name, Active: name == active})
+			}
+			sort.Slice(profiles, func(i, j int) bool { return profiles[i].Name < profiles[j].Name })
+			return render(cmd, profiles)
+		},
+	}
+}
+
+type profileEntry struct {
+	Name   string `json:"name" yaml:"name"`
+	Active bool   `json:"active" yaml:"active"`
+}
+
+type profileList []profileEntry
+
+func (profiles profileList) WriteTable(w io.Writer, opts output.Options) error {
+	if len(profiles) == 0 {
+		_, err := fmt.Fprintln(w, i18n.T("No profiles configured"))
+		return err
+	}
+	t := table.New(i18n.T("NAME"), i18n.T("ACTIVE"))
+	for _, p := range profiles {
+		active := ""
+		if p.Active {
+			active = "*"
+		}
+		row := t.AddRow(p.Name, active)
+		if p.Active {
+			t.SetRowStyle(row, output.Success)
+		}
+	}
+	return t.Render(w, opts)
+}
+
+func profileUseCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "use <name>",
+		Short: i18n.T("Select the profile used by default"),
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runProfileUse(cmd.Context(), args[0])
+		},
+	}
+}
+
+func runProfileUse(ctx context.Context, name string) error {
+	path, err := config.Path()
+	if err != nil {
+		return err
+	}
+	c, err := config.LoadFile(path)
+	if err != nil {
+		return err
+	}
+	if _, ok := c.Profiles[name]; !ok {
+		return exit.New(exit.NotFound, i18n.Errorf("unknown profile %q, create it with `sak --profile %s config set <key> <value>`", name, name))
+	}
+	c.Profile = name
+	return c.Save(ctx, path)
+}
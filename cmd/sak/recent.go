@@ -0,0 +1,212 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hezhizhen/sak/pkg/config"
+	"github.com/hezhizhen/sak/pkg/dryrun"
+	"github.com/hezhizhen/sak/pkg/exit"
+	"github.com/hezhizhen/sak/pkg/history"
+	"github.com/hezhizhen/sak/pkg/i18n"
+	"github.com/hezhizhen/sak/pkg/output"
+	"github.com/hezhizhen/sak/pkg/table"
+	"github.com/hezhizhen/sak/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func recentCmd() *cobra.Command {
+	var (
+		limit int
+		rerun int
+		span  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "recent",
+		Short: i18n.T("List or re-run recent sak invocations"),
+		Long: i18n.T(`List or re-run recent sak invocations
+
+Every sak invocation is recorded in sak/history.jsonl under $XDG_STATE_HOME
+(default ~/.local/state) unless the history config key is set to false.
+
+Example - list the last 20 invocations:
+  sak recent
+
+Example - list what ran last week:
+  sak recent --range last-week -n 0
+
+Example - re-run invocation 42:
+  sak recent --rerun 42
+`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			entries, err := history.Load()
+			if err != nil {
+				return err
+			}
+			if rerun > 0 {
+				return runRecentRerun(cmd.Context(), entries, rerun)
+			}
+			list := recentEntries(entries)
+			if span != "" {
+				start, end, err := utils.ParseDateRange(span, time.Now())
+				if err != nil {
+					return err
+				}
+				list = list.between(start, end)
+			}
+			return render(cmd, list.last(limit))
+		},
+	}
+
+	cmd.Flags().IntVarP(&limit, "limit", "n", 20, i18n.T("number of invocations to list"))
+	cmd.Flags().StringVar(&span, "range", "", i18n.T("only list invocations in a date range, e.g. today, last-week or 2025-01..2025-03"))
+	cmd.Flags().IntVar(&rerun, "rerun", 0, i18n.T("re-run the invocation with this ID"))
+
+	return cmd
+}
+
+type recentList []history.Entry
+
+func recentEntries(entries []history.Entry) recentList {
+	return append(recentList{}, entries...)
+}
+
+// between keeps the entries recorded in [start, end)
+func (list recentList) between(start, end time.Time) recentList {
+	kept := recentList{}
+	for _, e := range list {
+		if !e.Time.Before(start) && e.Time.Before(end) {
+			kept = append(kept, e)
+		}
+	}
+	return kept
+}
+
+// last keeps the last limit entries, or all of them if limit is not positive
+func (list recentList) last(limit int) recentList {
+	if limit > 0 && len(list) > limit {
+		return list[len(list)-limit:]
+	}
+	return list
+}
+
+func (list recentList) WriteTable(w io.Writer, opts output.Options) error {
+	t := table.New(i18n.T("ID"), i18n.T("TIME"), i18n.T("EXIT"), i18n.T("COMMAND"))
+	t.SetAlign(0, table.Right)
+	t.SetAlign(2, table.Right)
+	for _, e := range list {
+		row := t.AddRow(strconv.Itoa(e.ID), e.Time.Local().Format("2006-01-02 15:04:05"), strconv.Itoa(e.ExitCode), commandLine(e.Args))
+		if e.ExitCode != 0 {
+			t.SetRowStyle(row, output.Error)
+		}
+	}
+	return t.Render(w, opts)
+}
+
+func runRecentRerun(ctx context.Context, entries []history.Entry, id int) error {
+	var entry *history.Entry
+	for i := range entries {
+		if entries[i].ID == id {
+			entry = &entries[i]
+		}
+	}
+	if entry == nil {
+		return exit.New(exit.NotFound, i18n.Errorf("no invocation with ID %d", id))
+	}
+	if entry.Redacted {
+		return exit.New(exit.Usage, i18n.Errorf("invocation %d had secrets removed from its arguments and cannot be re-run", id))
+	}
+	args := entry.Args
+	fmt.Fprintln(os.Stderr, commandLine(args))
+
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	rerunCmd := exec.Command(exe, args...)
+	rerunCmd.Stdin = os.Stdin
+	rerunCmd.Stdout = os.Stdout
+	rerunCmd.Stderr = os.Stderr
+	err = dryrun.Run(ctx, rerunCmd)
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exit.New(exitErr.ExitCode(), err)
+	}
+	return err
+}
+
+// commandLine formats args as a shell command line
+func commandLine(args []string) string {
+	parts := []string{"sak"}
+	for _, arg := range args {
+		if arg == "" || strings.ContainsAny(arg, " \t\"'\\$") {
+			arg = strconv.Quote(arg)
+		}
+		parts = append(parts, arg)
+	}
+	return strings.Join(parts, " ")
+}
+
+// recordHistory appends an invocation to the history file. Shell completion
+// requests and listing the history itself are not recorded.
+func recordHistory(args []string, exitCode int) {
+	if name := commandName(args); strings.HasPrefix(name, "__") || name == "recent" {
+		return
+	}
+	if c, err := config.Load(); err != nil || !c.HistoryEnabled() {
+		return
+	}
+	args, redacted := redactArgs(args)
+	_ = history.Append(history.Entry{Time: time.Now(), Args: args, ExitCode: exitCode, Redacted: redacted})
+}
+
+// redactedArg replaces the secrets left out of the history
+const redactedArg = "***"
+
+// redactArgs returns args without the secrets they hold, the text copied
+// with clip copy and the values of secret config keys, and whether any was
+// found
+func redactArgs(args []string) ([]string, bool) {
+	cmd, rest, err := rootCmd().Find(args)
+	if err != nil || cmd.ParseFlags(rest) != nil {
+		return args, false
+	}
+	positional := cmd.Flags().Args()
+
+	var secrets []string
+	switch cmd.CommandPath() {
+	case "sak clip copy":
+		secrets = positional
+	case "sak config set":
+		if len(positional) != 2 {
+			break
+		}
+		if key, err := config.LookupKey(positional[0]); err == nil && key.Secret {
+			secrets = positional[1:]
+		}
+	}
+	if len(secrets) == 0 {
+		return args, false
+	}
+
+	redacted := make([]string, len(args))
+	for i, arg := range args {
+		redacted[i] = arg
+		for _, secret := range secrets {
+			if arg == secret {
+				redacted[i] = redactedArg
+			}
+		}
+	}
+	return redacted, true
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hezhizhen/sak/pkg/dryrun"
+	"github.com/hezhizhen/sak/pkg/exit"
+	"github.com/hezhizhen/sak/pkg/i18n"
+	"github.com/hezhizhen/sak/pkg/release"
+	"github.com/hezhizhen/sak/pkg/utils"
+	"github.com/hezhizhen/sak/pkg/version"
+
+	"github.com/spf13/cobra"
+)
+
+func selfUpdateCmd() *cobra.Command {
+	var check bool
+
+	cmd := &cobra.Command{
+		Use:   "self-update",
+		Short: i18n.T("Update sak to the latest release"),
+		Long: i18n.T(`Update sak to the latest release
+
+The binary for the current platform is downloaded from the latest GitHub
+release, verified against the release checksums and swapped in place of
+the running executable.
+
+Example - only report whether a newer version is available:
+  sak self-update --check
+
+Example - update without asking for confirmation:
+  sak self-update --yes
+`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSelfUpdate(cmd.Context(), check)
+		},
+	}
+
+	cmd.Flags().BoolVar(&check, "check", false, i18n.T("only report the available version"))
+
+	return cmd
+}
+
+func runSelfUpdate(ctx context.Context, check bool) error {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+
+	latest, err := release.Latest(ctx)
+	if err != nil {
+		return i18n.Errorf("check latest release: %w", err)
+	}
+	if version.Compare(latest.Version(), version.Version) <= 0 {
+		fmt.Println(i18n.Sprintf("sak is up to date (%s)", version.Version))
+		return nil
+	}
+	fmt.Println(i18n.Sprintf("A new version is available: %s -> %s", version.Version, latest.Version()))
+	if check {
+		fmt.Println(latest.HTMLURL)
+		return nil
+	}
+
+	name := release.AssetName()
+	binary, ok := latest.Asset(name)
+	if !ok {
+		return exit.New(exit.NotFound, i18n.Errorf("release %s has no binary for this platform (%s)", latest.TagName, name))
+	}
+	sums, ok := latest.Asset(release.ChecksumsAsset)
+	if !ok {
+		return exit.New(exit.NotFound, i18n.Errorf("release %s has no %s", latest.TagName, release.ChecksumsAsset))
+	}
+
+	ok, err = utils.Confirm(ctx, i18n.Sprintf("Update to %s?", latest.Version()), false)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return exit.New(exit.Aborted, i18n.Errorf("update cancelled"))
+	}
+
+	checksums, err := release.Download(ctx, sums)
+	if err != nil {
+		return i18n.Errorf("download checksums: %w", err)
+	}
+	data, err := release.Download(ctx, binary)
+	if err != nil {
+		return i18n.Errorf("download %s: %w", name, err)
+	}
+	if err := release.VerifyChecksum(checksums, name, data); err != nil {
+		return err
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	if exe, err = filepath.EvalSymlinks(exe); err != nil {
+		return err
+	}
+	description := i18n.Sprintf("replace %s with %s (%d bytes)", exe, latest.Version(), len(data))
+	if err := dryrun.Do(ctx, description, func() error { return replaceExecutable(exe, data) }); err != nil {
+		return i18n.Errorf("replace %s: %w", exe, err)
+	}
+	if dryrun.Enabled(ctx) {
+		return nil
+	}
+
+	fmt.Println(i18n.Sprintf("Updated %s to %s", exe, latest.Version()))
+	return nil
+}
+
+// replaceExecutable writes data next to path and renames it over path, so
+// the running binary is swapped atomically
+func replaceExecutable(path string, data []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".sak-update-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(info.Mode().Perm()); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hezhizhen/sak/pkg/history"
+	"github.com/hezhizhen/sak/pkg/i18n"
+	"github.com/hezhizhen/sak/pkg/utils"
+
+	"github.com/peterh/liner"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func shellCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "shell",
+		Short: i18n.T("Run sak commands in an interactive prompt"),
+		Long: i18n.T(`Run sak commands in an interactive prompt
+
+Each line is run as a sak command line without the leading "sak". Commands
+and flags are completed with Tab, and the prompt history is kept across
+sessions. Type "exit" or press Ctrl-D to leave.
+
+Example - start the prompt:
+  sak shell
+`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runShell()
+		},
+	}
+
+	return cmd
+}
+
+func runShell() error {
+	// liner keeps the terminal in raw mode between prompts, but commands
+	// reading the terminal expect the mode the shell was started in
+	cooked, cookedErr := liner.TerminalMode()
+	line := liner.NewLiner()
+	defer line.Close()
+	raw, rawErr := liner.TerminalMode()
+	switchModes := cookedErr == nil && rawErr == nil
+	line.SetCtrlCAborts(true)
+	line.SetCompleter(completeLine)
+
+	historyPath := ""
+	if path, err := history.Path(); err == nil {
+		historyPath = filepath.Join(filepath.Dir(path), "shell_history")
+		if f, err := os.Open(historyPath); err == nil {
+			_, _ = line.ReadHistory(f)
+			f.Close()
+		}
+	}
+
+	for {
+		input, err := line.Prompt("sak> ")
+		if errors.Is(err, liner.ErrPromptAborted) {
+			continue
+		}
+		if errors.Is(err, io.EOF) {
+			fmt.Println()
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		args, err := splitArgs(input)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T("Error:"), err)
+			continue
+		}
+		if len(args) == 0 {
+			continue
+		}
+		line.AppendHistory(input)
+		if args[0] == "exit" || args[0] == "quit" {
+			break
+		}
+		if args[0] == "shell" {
+			fmt.Fprintln(os.Stderr, i18n.T("Error:"), i18n.T("already in a sak shell"))
+			continue
+		}
+
+		if switchModes {
+			_ = cooked.ApplyMode()
+		}
+		runShellLine(args)
+		if switchModes {
+			_ = raw.ApplyMode()
+		}
+	}
+
+	if historyPath != "" {
+		if err := os.MkdirAll(filepath.Dir(historyPath), 0o755); err == nil {
+			var buf bytes.Buffer
+			if _, err := line.WriteHistory(&buf); err == nil {
+				_ = utils.WriteFileAtomic(historyPath, buf.Bytes(), 0o600)
+			}
+		}
+	}
+	return nil
+}
+
+// runShellLine runs a line of the shell. The profile and language selected
+// with --profile are process-wide, so they are restored afterwards to keep
+// them from leaking into the following lines.
+func runShellLine(args []string) {
+	profile, hasProfile := os.LookupEnv("SAK_PROFILE")
+	language := i18n.Current()
+	defer func() {
+		if hasProfile {
+			os.Setenv("SAK_PROFILE", profile)
+		} else {
+			os.Unsetenv("SAK_PROFILE")
+		}
+		i18n.SetLanguage(language)
+	}()
+
+	root := rootCmd()
+	if ok, code := dispatchPlugin(root, args); ok {
+		recordHistory(args, code)
+		return
+	}
+	root.SetArgs(args)
+	recordHistory(args, execute(root))
+}
+
+// completeLine completes the last word of input with the subcommands and
+// flags of the command named by the preceding words
+func completeLine(input string) []string {
+	words := strings.Fields(input)
+	prefix := ""
+	if len(words) > 0 && !strings.HasSuffix(input, " ") {
+		prefix = words[len(words)-1]
+		words = words[:len(words)-1]
+	}
+	head := input[:len(input)-len(prefix)]
+
+	cmd, _, err := rootCmd().Find(words)
+	if err != nil {
+		return nil
+	}
+
+	var candidates []string
+	if strings.HasPrefix(prefix, "-") {
+		cmd.Flags().VisitAll(func(f *pflag.Flag) {
+			candidates = append(candidates, "--"+f.Name)
+		})
+		cmd.InheritedFlags().VisitAll(func(f *pflag.Flag) {
+			candidates = append(candidates, "--"+f.Name)
+		})
+	} else {
+		for _, sub := range cmd.Commands() {
+			if sub.IsAvailableCommand() {
+				candidates = append(candidates, sub.Name())
+			}
+		}
+		if len(words) == 0 {
+			candidates = append(candidates, "exit")
+		}
+	}
+
+	var completions []string
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, prefix) {
+			completions = append(completions, head+candidate+" ")
+		}
+	}
+	return completions
+}
+
+// splitArgs splits a command line into arguments, honouring single and
+// double quotes and backslash escapes
+func splitArgs(input string) ([]string, error) {
+	var (
+		args    []string
+		current strings.Builder
+		inArg   bool
+		quote   rune
+		escaped bool
+	)
+	for _, r := range input {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inArg = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, i18n.Errorf("unterminated %c quote", quote)
+	}
+	if escaped {
+		return nil, i18n.Errorf("trailing backslash")
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
@@ -0,0 +1,263 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/hezhizhen/sak/pkg/clipboard"
+	"github.com/hezhizhen/sak/pkg/exit"
+	"github.com/hezhizhen/sak/pkg/i18n"
+	"github.com/hezhizhen/sak/pkg/output"
+	"github.com/hezhizhen/sak/pkg/snippet"
+	"github.com/hezhizhen/sak/pkg/table"
+	"github.com/hezhizhen/sak/pkg/utils"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/spf13/cobra"
+)
+
+func snippetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "snip",
+		Aliases: []string{"snippet"},
+		Short:   i18n.T("Store and copy snippets"),
+		Long: i18n.T(`Store and copy snippets
+
+Snippets are stored one file each in sak/snippets under $XDG_DATA_HOME
+(default ~/.local/share), so the directory can be kept in git. A file may
+start with a YAML front matter holding the description and tags.
+
+Example - save a one-liner:
+  sak snip add git-undo --tags git 'git reset --soft HEAD~1'
+
+Example - save a longer snippet from a file:
+  sak snip add nginx-proxy --tags nginx < proxy.conf
+
+Example - find a snippet and copy it to the clipboard:
+  sak snip search gitundo
+  sak snip copy git-undo
+`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(snippetAddCmd())
+	cmd.AddCommand(snippetListCmd())
+	cmd.AddCommand(snippetShowCmd())
+	cmd.AddCommand(snippetCopyCmd())
+	cmd.AddCommand(snippetSearchCmd())
+	cmd.AddCommand(snippetRemoveCmd())
+
+	return cmd
+}
+
+func snippetAddCmd() *cobra.Command {
+	var (
+		s     snippet.Snippet
+		force bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "add <name> [content...]",
+		Short: i18n.T("Add a snippet from the arguments or stdin"),
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s.Name = args[0]
+			s.Content = strings.Join(args[1:], " ")
+			if s.Content == "" {
+				if output.IsTerminal(os.Stdin) {
+					return exit.New(exit.Usage, i18n.Errorf("no content: pass it as arguments or on stdin"))
+				}
+				data, err := io.ReadAll(os.Stdin)
+				if err != nil {
+					return err
+				}
+				s.Content = string(data)
+			}
+			if strings.TrimSpace(s.Content) == "" {
+				return exit.New(exit.Usage, i18n.Errorf("no content: pass it as arguments or on stdin"))
+			}
+			s.Tags = cleanTags(s.Tags)
+			return snippet.Save(cmd.Context(), s, force)
+		},
+	}
+
+	cmd.Flags().StringVarP(&s.Description, "description", "d", "", i18n.T("what the snippet does"))
+	cmd.Flags().StringSliceVar(&s.Tags, "tags", nil, i18n.T("comma-separated tags"))
+	cmd.Flags().BoolVarP(&force, "force", "f", false, i18n.T("replace an existing snippet"))
+
+	return cmd
+}
+
+func snippetListCmd() *cobra.Command {
+	var tag string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: i18n.T("List snippets"),
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			snippets, err := snippet.List()
+			if err != nil {
+				return err
+			}
+			list := snippetList{}
+			for _, s := range snippets {
+				if tag == "" || containsFold(s.Tags, tag) {
+					list = append(list, s)
+				}
+			}
+			return render(cmd, list)
+		},
+	}
+
+	cmd.Flags().StringVar(&tag, "tag", "", i18n.T("only list snippets with this tag"))
+
+	return cmd
+}
+
+func snippetShowCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:               "show <name>",
+		Short:             i18n.T("Print a snippet"),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeSnippets,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s, err := snippet.Load(args[0])
+			if err != nil {
+				return err
+			}
+			fmt.Print(s.Content)
+			return nil
+		},
+	}
+}
+
+func snippetCopyCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:               "copy <name>",
+		Short:             i18n.T("Copy a snippet to the clipboard"),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeSnippets,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s, err := snippet.Load(args[0])
+			if err != nil {
+				return err
+			}
+			// a trailing newline would run a pasted one-liner right away
+			if err := clipboard.Copy(strings.TrimSuffix(s.Content, "\n")); err != nil {
+				return err
+			}
+			fmt.Fprintln(os.Stderr, i18n.Sprintf("Copied %s to the clipboard", s.Name))
+			return nil
+		},
+	}
+}
+
+func snippetSearchCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "search <query>",
+		Short: i18n.T("Fuzzy search snippets"),
+		Long: i18n.T(`Fuzzy search snippets
+
+The characters of the query must appear in order in the name, tags,
+description or content of a snippet. The best matches are listed first.
+
+Example - find "git reset --soft":
+  sak snip search gitsoft
+`),
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			snippets, err := snippet.List()
+			if err != nil {
+				return err
+			}
+			query := strings.Join(args, "")
+			scores := map[string]int{}
+			list := snippetList{}
+			for _, s := range snippets {
+				best, found := 0, false
+				// the name weighs more than the rest
+				for i, text := range []string{s.Name, strings.Join(s.Tags, " ") + " " + s.Description, s.Content} {
+					if score, ok := utils.FuzzyScore(query, text); ok {
+						if i == 0 {
+							score *= 2
+						}
+						if !found || score > best {
+							best, found = score, true
+						}
+					}
+				}
+				if found {
+					scores[s.Name] = best
+					list = append(list, s)
+				}
+			}
+			if len(list) == 0 {
+				return exit.New(exit.NotFound, i18n.Errorf("no snippets match %q", query))
+			}
+			sort.SliceStable(list, func(i, j int) bool { return scores[list[i].Name] > scores[list[j].Name] })
+			return render(cmd, list)
+		},
+	}
+}
+
+func snippetRemoveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:               "rm <name>",
+		Short:             i18n.T("Remove a snippet"),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeSnippets,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return snippet.Remove(cmd.Context(), args[0])
+		},
+	}
+}
+
+// completeSnippets completes the names of the stored snippets
+func completeSnippets(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	snippets, err := snippet.List()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	names := make([]string, 0, len(snippets))
+	for _, s := range snippets {
+		names = append(names, s.Name)
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// containsFold reports whether list contains s, ignoring case
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// snippetPreviewWidth is the width of the content column of snippet lists
+const snippetPreviewWidth = 50
+
+type snippetList []snippet.Snippet
+
+func (list snippetList) WriteTable(w io.Writer, opts output.Options) error {
+	t := table.New(i18n.T("NAME"), i18n.T("TAGS"), i18n.T("SNIPPET"))
+	for _, s := range list {
+		preview := strings.TrimSpace(s.Description)
+		if preview == "" {
+			preview = strings.Join(strings.Fields(s.Content), " ")
+		}
+		t.AddRow(s.Name, strings.Join(s.Tags, ","), runewidth.Truncate(preview, snippetPreviewWidth, "…"))
+	}
+	return t.Render(w, opts)
+}
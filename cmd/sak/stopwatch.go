@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hezhizhen/sak/pkg/i18n"
+	"github.com/hezhizhen/sak/pkg/output"
+	"github.com/hezhizhen/sak/pkg/table"
+	"github.com/hezhizhen/sak/pkg/timer"
+	"github.com/hezhizhen/sak/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func stopwatchCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "stopwatch",
+		Short: i18n.T("Measure elapsed time with laps"),
+		Long: i18n.T(`Measure elapsed time with laps
+
+Press Enter to complete a lap, and q then Enter or Ctrl-C to stop. The
+laps are listed when the stopwatch stops.
+
+Example - time a few rounds:
+  sak stopwatch
+
+Example - get the laps as JSON:
+  sak stopwatch --output json
+`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := outputOptions(cmd)
+			if err != nil {
+				return err
+			}
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			defer stop()
+
+			stdin, err := utils.OpenStdin()
+			if err != nil {
+				return err
+			}
+			defer stdin.Close()
+			lap := make(chan struct{})
+			go readLaps(ctx, stdin, lap, stop)
+			display := timer.NewDisplay(os.Stderr, opts)
+			return render(cmd, stopwatchLaps(display.Stopwatch(ctx, i18n.T("Stopwatch"), lap)))
+		},
+	}
+}
+
+// readLaps signals lap for every empty line of r, and calls stop on "q" or
+// at the end of the input. It returns once ctx is done and r is closed.
+func readLaps(ctx context.Context, r io.Reader, lap chan<- struct{}, stop func()) {
+	defer stop()
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if strings.EqualFold(strings.TrimSpace(scanner.Text()), "q") {
+			return
+		}
+		select {
+		case lap <- struct{}{}:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+type stopwatchLap struct {
+	Lap     int     `json:"lap" yaml:"lap"`
+	Seconds float64 `json:"seconds" yaml:"seconds"`
+	Total   float64 `json:"total" yaml:"total"`
+}
+
+type stopwatchList []stopwatchLap
+
+func stopwatchLaps(laps []time.Duration) stopwatchList {
+	list := stopwatchList{}
+	var total time.Duration
+	for i, d := range laps {
+		d = d.Round(time.Millisecond)
+		total += d
+		list = append(list, stopwatchLap{Lap: i + 1, Seconds: d.Seconds(), Total: total.Seconds()})
+	}
+	return list
+}
+
+func (list stopwatchList) WriteTable(w io.Writer, opts output.Options) error {
+	t := table.New(i18n.T("LAP"), i18n.T("TIME"), i18n.T("TOTAL"))
+	for column := 0; column < 3; column++ {
+		t.SetAlign(column, table.Right)
+	}
+	for _, lap := range list {
+		t.AddRow(strconv.Itoa(lap.Lap), timer.ClockTenths(seconds(lap.Seconds)), timer.ClockTenths(seconds(lap.Total)))
+	}
+	return t.Render(w, opts)
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/hezhizhen/sak/pkg/exit"
+	"github.com/hezhizhen/sak/pkg/i18n"
+	"github.com/hezhizhen/sak/pkg/timer"
+	"github.com/hezhizhen/sak/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func timerCmd() *cobra.Command {
+	var (
+		at       string
+		notifyOn bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "timer [duration] [label]",
+		Short: i18n.T("Count down and notify when time is up"),
+		Long: i18n.T(`Count down and notify when time is up
+
+The duration is written like 10m, 1h30m, 1:30 or 2.5h. With --at the timer
+runs until a time of day instead, and the only argument is the label. A
+desktop notification is sent when time is up. Press Ctrl-C to cancel.
+
+Example - a tea timer:
+  sak timer 4m tea
+
+Example - remind me at 7pm:
+  sak timer --at 7pm "call home"
+`),
+		Args: cobra.RangeArgs(0, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var (
+				d     time.Duration
+				label = i18n.T("Timer")
+				err   error
+			)
+			switch {
+			case at != "" && len(args) <= 1:
+				if d, err = untilClock(at, time.Now()); err != nil {
+					return err
+				}
+				if len(args) == 1 {
+					label = args[0]
+				}
+			case at == "" && len(args) >= 1:
+				if d, err = utils.ParseDuration(args[0]); err != nil {
+					return err
+				}
+				if d <= 0 {
+					return exit.New(exit.Usage, i18n.Errorf("the duration must be positive"))
+				}
+				if len(args) == 2 {
+					label = args[1]
+				}
+			default:
+				return exit.New(exit.Usage, i18n.Errorf("expected a duration, or --at with an optional label"))
+			}
+
+			opts, err := outputOptions(cmd)
+			if err != nil {
+				return err
+			}
+			return runTimer(cmd.Context(), timer.NewDisplay(os.Stdout, opts), label, d, notifyOn)
+		},
+	}
+
+	cmd.Flags().StringVar(&at, "at", "", i18n.T("count down to a time of day, e.g. 19:05 or 7pm"))
+	cmd.Flags().BoolVar(&notifyOn, "notify", true, i18n.T("send desktop notifications"))
+
+	return cmd
+}
+
+func runTimer(ctx context.Context, display *timer.Display, label string, d time.Duration, notifyOn bool) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	if err := display.Countdown(ctx, label, d); err != nil {
+		if errors.Is(err, context.Canceled) {
+			return exit.New(exit.Aborted, i18n.Errorf("timer cancelled"))
+		}
+		return err
+	}
+	desktopNotifier(notifyOn)(i18n.T("Time is up"), label)
+	return nil
+}
+
+// untilClock returns the time from now until the next occurrence of the
+// time of day s
+func untilClock(s string, now time.Time) (time.Duration, error) {
+	offset, err := utils.ParseClock(s)
+	if err != nil {
+		return 0, err
+	}
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	target := midnight.Add(offset)
+	if !target.After(now) {
+		target = target.AddDate(0, 0, 1)
+	}
+	return target.Sub(now), nil
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hezhizhen/sak/pkg/exit"
+	"github.com/hezhizhen/sak/pkg/i18n"
+	"github.com/hezhizhen/sak/pkg/output"
+	"github.com/hezhizhen/sak/pkg/table"
+	"github.com/hezhizhen/sak/pkg/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// tsLayout is how `sak ts` shows times in a zone
+const tsLayout = "2006-01-02 15:04:05 -07:00 MST"
+
+func tsCmd() *cobra.Command {
+	var (
+		toEpoch bool
+		zones   []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "ts [time]",
+		Short: i18n.T("Convert between Unix timestamps, dates and time zones"),
+		Long: i18n.T(`Convert between Unix timestamps, dates and time zones
+
+The time is "now" (the default), a Unix timestamp in seconds, milliseconds,
+microseconds or nanoseconds, or a date such as 2025-07-16 09:00 or
+2025-07-16T09:00:00+08:00. Dates without a zone are in the local zone.
+
+It is shown as a timestamp, in UTC, in the local zone and in the zones
+given with --in, which take IANA names such as Asia/Shanghai.
+
+Example - show a timestamp as a date:
+  sak ts 1721102400
+
+Example - print the timestamp of a date:
+  sak ts "2025-07-16 09:00" --to-epoch
+
+Example - show the time in Shanghai and New York:
+  sak ts now --in Asia/Shanghai,America/New_York
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			text := strings.Join(args, " ")
+			if text == "" {
+				text = "now"
+			}
+			t, err := utils.ParseTime(text, time.Local, time.Now())
+			if err != nil {
+				return err
+			}
+			if toEpoch {
+				fmt.Println(t.Unix())
+				return nil
+			}
+
+			result := &tsResult{
+				Epoch:       t.Unix(),
+				EpochMillis: t.UnixMilli(),
+				UTC:         t.UTC().Format(time.RFC3339Nano),
+				Local:       t.Local().Format(time.RFC3339Nano),
+				Relative:    utils.HumanizeSince(t),
+				Zones:       []tsZone{},
+				time:        t,
+			}
+			for _, name := range zones {
+				loc, err := time.LoadLocation(strings.TrimSpace(name))
+				if err != nil {
+					return exit.New(exit.Usage, i18n.Errorf("unknown time zone %q", name))
+				}
+				result.Zones = append(result.Zones, tsZone{Zone: loc.String(), Time: t.In(loc).Format(time.RFC3339Nano), time: t.In(loc)})
+			}
+			return render(cmd, result)
+		},
+	}
+
+	cmd.Flags().BoolVar(&toEpoch, "to-epoch", false, i18n.T("only print the Unix timestamp in seconds"))
+	cmd.Flags().StringSliceVar(&zones, "in", nil, i18n.T("comma-separated time zones to show the time in"))
+
+	return cmd
+}
+
+type tsZone struct {
+	Zone string `json:"zone" yaml:"zone"`
+	Time string `json:"time" yaml:"time"`
+	time time.Time
+}
+
+type tsResult struct {
+	Epoch       int64    `json:"epoch" yaml:"epoch"`
+	EpochMillis int64    `json:"epoch_ms" yaml:"epoch_ms"`
+	UTC         string   `json:"utc" yaml:"utc"`
+	Local       string   `json:"local" yaml:"local"`
+	Relative    string   `json:"relative" yaml:"relative"`
+	Zones       []tsZone `json:"zones" yaml:"zones"`
+	time        time.Time
+}
+
+func (r *tsResult) WriteTable(w io.Writer, opts output.Options) error {
+	t := table.NewKeyValue()
+	t.AddRow(i18n.T("Epoch"), strconv.FormatInt(r.Epoch, 10))
+	t.AddRow(i18n.T("Epoch (ms)"), strconv.FormatInt(r.EpochMillis, 10))
+	t.AddRow(i18n.T("UTC"), r.time.UTC().Format(tsLayout))
+	t.AddRow(i18n.T("Local"), r.time.Local().Format(tsLayout))
+	for _, z := range r.Zones {
+		row := t.AddRow(z.Zone, z.time.Format(tsLayout))
+		t.SetRowStyle(row, output.Accent)
+	}
+	t.AddRow(i18n.T("Relative"), r.Relative)
+	return t.Render(w, opts)
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hezhizhen/sak/pkg/config"
+	"github.com/hezhizhen/sak/pkg/i18n"
+	"github.com/hezhizhen/sak/pkg/output"
+	"github.com/hezhizhen/sak/pkg/release"
+	"github.com/hezhizhen/sak/pkg/utils"
+	"github.com/hezhizhen/sak/pkg/version"
+
+	"github.com/spf13/cobra"
+)
+
+// noticeSkipped lists the commands after which the update notice is never
+// shown, either because they already report the version or because their
+// output is consumed by another program
+var noticeSkipped = map[string]bool{
+	"version":     true,
+	"self-update": true,
+	"completion":  true,
+	"help":        true,
+	"shell":       true,
+}
+
+// notifyUpdate prints a one-line notice to stderr if a newer release exists.
+// The check runs at most once a day, and only for interactive use without
+// --quiet.
+func notifyUpdate(root *cobra.Command, args []string) {
+	if name := commandName(args); name == "" || noticeSkipped[name] || strings.HasPrefix(name, "__") {
+		return
+	}
+	if quiet, _ := root.PersistentFlags().GetBool("quiet"); quiet || !output.IsTerminal(os.Stderr) {
+		return
+	}
+	c, err := config.Load()
+	if err != nil || !c.UpdateNoticeEnabled() {
+		return
+	}
+	if !noticeDue() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	latest, err := release.LatestCached(ctx, 24*time.Hour)
+	if err != nil || version.Compare(latest.Version(), version.Version) <= 0 {
+		return
+	}
+
+	opts := output.Options{Theme: c.OutputTheme()}
+	if noColor, _ := root.PersistentFlags().GetBool("no-color"); !noColor {
+		opts.Color = output.ColorEnabled(c.Color, os.Stderr)
+	}
+	notice := i18n.Sprintf("A new release of sak is available: %s -> %s, released %s (run `sak self-update`)",
+		version.Version, latest.Version(), utils.HumanizeSince(latest.PublishedAt))
+	fmt.Fprintln(os.Stderr, opts.Style(output.Warn, notice))
+}
+
+// noticeDue reports whether a day has passed since the last check and marks
+// the check as done, so an unreachable GitHub doesn't slow every command down
+func noticeDue() bool {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return false
+	}
+	stamp := filepath.Join(dir, "sak", "update-notice")
+	if info, err := os.Stat(stamp); err == nil && time.Since(info.ModTime()) < 24*time.Hour {
+		return false
+	}
+	if err := os.MkdirAll(filepath.Dir(stamp), 0o755); err != nil {
+		return false
+	}
+	return os.WriteFile(stamp, nil, 0o644) == nil
+}
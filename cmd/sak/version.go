@@ -1,54 +1,99 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"os"
 	"runtime"
-	"strings"
+	"time"
 
+	"github.com/hezhizhen/sak/pkg/i18n"
+	"github.com/hezhizhen/sak/pkg/output"
+	"github.com/hezhizhen/sak/pkg/release"
+	"github.com/hezhizhen/sak/pkg/table"
 	"github.com/hezhizhen/sak/pkg/version"
 
 	"github.com/spf13/cobra"
 )
 
 func versionCmd() *cobra.Command {
+	var checkLatest bool
+
 	cmd := &cobra.Command{
 		Use:   "version",
-		Short: "Show the sak version information",
-		Long: `Show the sak version information
+		Short: i18n.T("Show the sak version information"),
+		Long: i18n.T(`Show the sak version information
 
 Example - print version:
   sak version
-`,
+
+Example - also check whether a newer release exists:
+  sak version --check-latest
+
+Example - print version as JSON:
+  sak version --output json
+`),
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runVersion()
+			info, err := runVersion(cmd.Context(), checkLatest)
+			if err != nil {
+				return err
+			}
+			return render(cmd, info)
 		},
 	}
 
+	cmd.Flags().BoolVar(&checkLatest, "check-latest", false, i18n.T("check GitHub for a newer release"))
+
 	return cmd
 }
 
-func runVersion() error {
-	items := [][]string{
-		{"Version", version.GetVersion()},
-		{"Go version", runtime.Version()},
-	}
-	if version.GitCommit != "" {
-		items = append(items, []string{"Git commit", version.GitCommit})
+type versionInfo struct {
+	Version         string `json:"version" yaml:"version"`
+	GoVersion       string `json:"goVersion" yaml:"goVersion"`
+	GitCommit       string `json:"gitCommit,omitempty" yaml:"gitCommit,omitempty"`
+	GitTreeState    string `json:"gitTreeState,omitempty" yaml:"gitTreeState,omitempty"`
+	LatestVersion   string `json:"latestVersion,omitempty" yaml:"latestVersion,omitempty"`
+	UpdateAvailable bool   `json:"updateAvailable,omitempty" yaml:"updateAvailable,omitempty"`
+}
+
+func runVersion(ctx context.Context, checkLatest bool) (*versionInfo, error) {
+	info := &versionInfo{
+		Version:      version.GetVersion(),
+		GoVersion:    runtime.Version(),
+		GitCommit:    version.GitCommit,
+		GitTreeState: version.GitTreeState,
 	}
-	if version.GitTreeState != "" {
-		items = append(items, []string{"Git tree state", version.GitTreeState})
+	if checkLatest {
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		latest, err := release.LatestCached(ctx, time.Hour)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, i18n.Sprintf("failed to check latest release: %v", err))
+		} else {
+			info.LatestVersion = latest.Version()
+			info.UpdateAvailable = version.Compare(latest.Version(), version.Version) > 0
+		}
 	}
+	return info, nil
+}
 
-	size := 0
-	for _, item := range items {
-		if length := len(item[0]); length > size {
-			size = length
-		}
+func (info *versionInfo) WriteTable(w io.Writer, opts output.Options) error {
+	t := table.NewKeyValue()
+	t.AddRow(i18n.T("Version"), info.Version)
+	t.AddRow(i18n.T("Go version"), info.GoVersion)
+	if info.GitCommit != "" {
+		t.AddRow(i18n.T("Git commit"), info.GitCommit)
 	}
-	for _, item := range items {
-		fmt.Println(item[0] + ": " + strings.Repeat(" ", size-len(item[0])) + item[1])
+	if info.GitTreeState != "" {
+		t.AddRow(i18n.T("Git tree state"), info.GitTreeState)
 	}
-
-	return nil
+	if info.UpdateAvailable {
+		row := t.AddRow(i18n.T("Latest version"), i18n.Sprintf("%s (run `sak self-update` to upgrade)", info.LatestVersion))
+		t.SetRowStyle(row, output.Warn)
+	} else if info.LatestVersion != "" {
+		t.AddRow(i18n.T("Latest version"), i18n.Sprintf("%s (up to date)", info.LatestVersion))
+	}
+	return t.Render(w, opts)
 }
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/hezhizhen/sak/pkg/config"
+	"github.com/hezhizhen/sak/pkg/exit"
+	"github.com/hezhizhen/sak/pkg/i18n"
+	"github.com/hezhizhen/sak/pkg/output"
+	"github.com/hezhizhen/sak/pkg/table"
+	"github.com/hezhizhen/sak/pkg/weather"
+
+	"github.com/spf13/cobra"
+)
+
+func weatherCmd() *cobra.Command {
+	var (
+		days  int
+		short bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "weather [city]",
+		Short: i18n.T("Show the current weather and a forecast"),
+		Long: i18n.T(`Show the current weather and a forecast
+
+The city defaults to the weather-city config key. The weather is fetched
+from Open-Meteo, which needs no API key, unless the weather-provider config
+key selects openweathermap, whose API key is set with the weather-key
+config key. Temperatures are in °C and wind speeds in km/h.
+
+With --short, a single line is printed, which suits status bars and
+templates.
+
+Example - show the weather in Shanghai for the next 5 days:
+  sak weather Shanghai --days 5
+
+Example - set the default city and print a single line:
+  sak config set weather-city Berlin
+  sak weather --short
+`),
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if days < 1 || days > 5 {
+				return exit.New(exit.Usage, i18n.Errorf("--days must be between 1 and 5"))
+			}
+			c, err := config.Load()
+			if err != nil {
+				return err
+			}
+			city := strings.Join(args, " ")
+			if city == "" {
+				city = c.WeatherCity
+			}
+			if strings.TrimSpace(city) == "" {
+				return exit.New(exit.Usage, i18n.Errorf("no city given, pass one or set it with sak config set weather-city"))
+			}
+			provider, err := weather.NewProvider(c.WeatherProvider, c.WeatherKey)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+			defer cancel()
+			report, err := provider.Fetch(ctx, city, days)
+			if err != nil {
+				return err
+			}
+			if short {
+				fmt.Println(weatherLine(report))
+				return nil
+			}
+			return render(cmd, weatherReport{*report})
+		},
+	}
+
+	cmd.Flags().IntVar(&days, "days", 3, i18n.T("number of days to forecast, from 1 to 5"))
+	cmd.Flags().BoolVarP(&short, "short", "s", false, i18n.T("print the weather on a single line"))
+
+	return cmd
+}
+
+// weatherLine summarizes a report on a line, e.g.
+// "Berlin, Germany: Partly cloudy, 18°C (12–21°C)"
+func weatherLine(r *weather.Report) string {
+	line := i18n.Sprintf("%[1]s: %[2]s, %[3]s", r.Place, r.Current.Summary, temperature(r.Current.Temperature))
+	if len(r.Days) > 0 {
+		line += fmt.Sprintf(" (%s–%s)", temperature(r.Days[0].Min), temperature(r.Days[0].Max))
+	}
+	return line
+}
+
+func temperature(t float64) string {
+	t = math.Round(t)
+	if t == 0 {
+		// not -0°C
+		t = 0
+	}
+	return fmt.Sprintf("%.0f°C", t)
+}
+
+type weatherReport struct {
+	weather.Report `yaml:",inline"`
+}
+
+func (r weatherReport) WriteTable(w io.Writer, opts output.Options) error {
+	fmt.Fprintln(w, opts.Style(output.Heading, r.Place))
+	now := table.NewKeyValue()
+	now.AddRow(i18n.T("Conditions"), r.Current.Summary)
+	now.AddRow(i18n.T("Temperature"), temperature(r.Current.Temperature))
+	now.AddRow(i18n.T("Feels like"), temperature(r.Current.FeelsLike))
+	now.AddRow(i18n.T("Humidity"), fmt.Sprintf("%d%%", r.Current.Humidity))
+	now.AddRow(i18n.T("Wind"), fmt.Sprintf("%.0f km/h", r.Current.Wind))
+	if err := now.Render(w, opts); err != nil {
+		return err
+	}
+	if len(r.Days) == 0 {
+		return nil
+	}
+
+	fmt.Fprintln(w)
+	t := table.New(i18n.T("DATE"), i18n.T("CONDITIONS"), i18n.T("MIN"), i18n.T("MAX"), i18n.T("PRECIPITATION"))
+	for _, column := range []int{2, 3, 4} {
+		t.SetAlign(column, table.Right)
+	}
+	for _, d := range r.Days {
+		t.AddRow(d.Date, d.Summary, temperature(d.Min), temperature(d.Max), fmt.Sprintf("%d%%", d.Precipitation))
+	}
+	return t.Render(w, opts)
+}
@@ -2,9 +2,17 @@ module github.com/hezhizhen/sak
 
 go 1.19
 
-require github.com/spf13/cobra v1.8.0
+require (
+	github.com/mattn/go-runewidth v0.0.3
+	github.com/peterh/liner v1.2.2
+	github.com/spf13/cobra v1.8.0
+	github.com/spf13/pflag v1.0.5
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
+	github.com/cpuguy83/go-md2man/v2 v2.0.3 // indirect
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
-	github.com/spf13/pflag v1.0.5 // indirect
+	github.com/russross/blackfriday/v2 v2.1.0 // indirect
+	golang.org/x/sys v0.0.0-20211117180635-dee7805ff2e1 // indirect
 )
@@ -0,0 +1,166 @@
+package bookmark
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hezhizhen/sak/pkg/config"
+	"github.com/hezhizhen/sak/pkg/dryrun"
+	"github.com/hezhizhen/sak/pkg/exit"
+	"github.com/hezhizhen/sak/pkg/i18n"
+)
+
+// Bookmark is a saved URL
+type Bookmark struct {
+	ID    int       `json:"id" yaml:"id"`
+	URL   string    `json:"url" yaml:"url"`
+	Title string    `json:"title,omitempty" yaml:"title,omitempty"`
+	Tags  []string  `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Added time.Time `json:"added" yaml:"added"`
+}
+
+// Matches reports whether every word of query occurs in the title, URL or
+// tags of b, ignoring case
+func (b Bookmark) Matches(query string) bool {
+	text := strings.ToLower(b.Title + " " + b.URL + " " + strings.Join(b.Tags, " "))
+	for _, word := range strings.Fields(strings.ToLower(query)) {
+		if !strings.Contains(text, word) {
+			return false
+		}
+	}
+	return true
+}
+
+// HasTag reports whether b is tagged with tag
+func (b Bookmark) HasTag(tag string) bool {
+	for _, t := range b.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// Path returns the location of the bookmarks file, bookmarks.json next to
+// the configuration file so both can be synced together
+func Path() (string, error) {
+	path, err := config.Path()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), "bookmarks.json"), nil
+}
+
+// Load returns the saved bookmarks ordered by ID
+func Load() ([]Bookmark, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var bookmarks []Bookmark
+	if err := json.Unmarshal(data, &bookmarks); err != nil {
+		return nil, exit.New(exit.ParseError, i18n.Errorf("parse %s: %w", path, err))
+	}
+	sort.Slice(bookmarks, func(i, j int) bool { return bookmarks[i].ID < bookmarks[j].ID })
+	return bookmarks, nil
+}
+
+// Save writes the bookmarks file
+func Save(ctx context.Context, bookmarks []Bookmark) error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(bookmarks, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := dryrun.MkdirAll(ctx, filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return dryrun.WriteFile(ctx, path, append(data, '\n'), 0o644)
+}
+
+// NextID returns the ID for a new bookmark
+func NextID(bookmarks []Bookmark) int {
+	id := 0
+	for _, b := range bookmarks {
+		if b.ID > id {
+			id = b.ID
+		}
+	}
+	return id + 1
+}
+
+// Find returns the index of the bookmark with the given ID
+func Find(bookmarks []Bookmark, id int) (int, error) {
+	for i, b := range bookmarks {
+		if b.ID == id {
+			return i, nil
+		}
+	}
+	return 0, exit.New(exit.NotFound, i18n.Errorf("no bookmark with ID %d", id))
+}
+
+// NormalizeURL validates raw as an http(s) URL, assuming https when the
+// scheme is missing
+func NormalizeURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", exit.New(exit.Usage, i18n.Errorf("invalid URL %q", raw))
+	}
+	return u.String(), nil
+}
+
+// titlePattern finds the title element of an HTML page
+var titlePattern = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+
+// FetchTitle downloads the page at rawURL and returns its title
+func FetchTitle(ctx context.Context, rawURL string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("User-Agent", "sak")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", i18n.Errorf("GET %s: %s", rawURL, resp.Status)
+	}
+
+	// the title is in the head, so the start of the page is enough
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 512*1024))
+	if err != nil {
+		return "", err
+	}
+	match := titlePattern.FindSubmatch(data)
+	if match == nil {
+		return "", i18n.Errorf("%s has no title", rawURL)
+	}
+	return strings.Join(strings.Fields(html.UnescapeString(string(match[1]))), " "), nil
+}
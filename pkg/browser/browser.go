@@ -0,0 +1,22 @@
+package browser
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+// Command returns the command opening url in the default browser: $BROWSER
+// if set, otherwise open on macOS, rundll32 on Windows and xdg-open elsewhere
+func Command(url string) *exec.Cmd {
+	if browser := os.Getenv("BROWSER"); browser != "" {
+		return exec.Command(browser, url)
+	}
+	switch runtime.GOOS {
+	case "darwin":
+		return exec.Command("open", url)
+	case "windows":
+		return exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	}
+	return exec.Command("xdg-open", url)
+}
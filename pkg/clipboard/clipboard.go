@@ -0,0 +1,82 @@
+package clipboard
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/hezhizhen/sak/pkg/exit"
+	"github.com/hezhizhen/sak/pkg/i18n"
+)
+
+// backend is a pair of commands reading and writing the clipboard
+type backend struct {
+	copy  []string
+	paste []string
+}
+
+// backends returns the clipboard tools to try on this system, best first
+func backends() []backend {
+	switch runtime.GOOS {
+	case "darwin":
+		return []backend{{[]string{"pbcopy"}, []string{"pbpaste"}}}
+	case "windows":
+		return []backend{{[]string{"clip"}, []string{"powershell", "-NoProfile", "-Command", "Get-Clipboard"}}}
+	}
+	var list []backend
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		list = append(list, backend{[]string{"wl-copy"}, []string{"wl-paste", "--no-newline"}})
+	}
+	return append(list,
+		backend{[]string{"xclip", "-selection", "clipboard"}, []string{"xclip", "-selection", "clipboard", "-out"}},
+		backend{[]string{"xsel", "--clipboard", "--input"}, []string{"xsel", "--clipboard", "--output"}},
+	)
+}
+
+// find returns the first backend whose tool is installed
+func find() (backend, error) {
+	for _, b := range backends() {
+		if _, err := exec.LookPath(b.copy[0]); err == nil {
+			return b, nil
+		}
+	}
+	return backend{}, exit.New(exit.ToolMissing, i18n.Errorf("no clipboard tool found (install wl-clipboard, xclip or xsel)"))
+}
+
+// Copy replaces the content of the system clipboard with text
+func Copy(text string) error {
+	b, err := find()
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command(b.copy[0], b.copy[1:]...)
+	cmd.Stdin = strings.NewReader(text)
+	// no output pipes: xclip and wl-copy keep running in the background to
+	// serve the clipboard and would hold them open
+	if err := cmd.Run(); err != nil {
+		return i18n.Errorf("copy to clipboard: %w", err)
+	}
+	return nil
+}
+
+// Paste returns the content of the system clipboard
+func Paste() (string, error) {
+	b, err := find()
+	if err != nil {
+		return "", err
+	}
+	var stderr bytes.Buffer
+	cmd := exec.Command(b.paste[0], b.paste[1:]...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", i18n.Errorf("paste from clipboard: %w: %s", err, bytes.TrimSpace(stderr.Bytes()))
+	}
+	if runtime.GOOS == "windows" {
+		// Get-Clipboard ends its output with a line break
+		out = bytes.TrimSuffix(bytes.TrimSuffix(out, []byte("\n")), []byte("\r"))
+	}
+	return string(out), nil
+}
@@ -0,0 +1,102 @@
+package clipboard
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hezhizhen/sak/pkg/config"
+	"github.com/hezhizhen/sak/pkg/dryrun"
+)
+
+// Entry is a text copied to the clipboard
+type Entry struct {
+	Time time.Time `json:"time" yaml:"time"`
+	Text string    `json:"text" yaml:"text"`
+}
+
+// HistoryPath returns the location of the clipboard history,
+// clipboard.jsonl in the state directory
+func HistoryPath() (string, error) {
+	dir, err := config.StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "clipboard.jsonl"), nil
+}
+
+// History returns the remembered texts, oldest first
+func History() ([]Entry, error) {
+	path, err := HistoryPath()
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16<<20)
+	for scanner.Scan() {
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err == nil {
+			entries = append(entries, e)
+		}
+	}
+	return entries, scanner.Err()
+}
+
+// Remember adds e to the history, which keeps the size latest distinct
+// texts. An earlier copy of the same text moves to the end.
+func Remember(ctx context.Context, e Entry, size int) error {
+	entries, err := History()
+	if err != nil {
+		return err
+	}
+	kept := entries[:0]
+	for _, old := range entries {
+		if old.Text != e.Text {
+			kept = append(kept, old)
+		}
+	}
+	kept = append(kept, e)
+	if len(kept) > size {
+		kept = kept[len(kept)-size:]
+	}
+	return writeHistory(ctx, kept)
+}
+
+// ClearHistory forgets all remembered texts
+func ClearHistory(ctx context.Context) error {
+	return writeHistory(ctx, nil)
+}
+
+func writeHistory(ctx context.Context, entries []Entry) error {
+	path, err := HistoryPath()
+	if err != nil {
+		return err
+	}
+	var data []byte
+	for _, e := range entries {
+		line, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		data = append(append(data, line...), '\n')
+	}
+	if err := dryrun.MkdirAll(ctx, filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	// copied texts may be secrets
+	return dryrun.WriteFile(ctx, path, data, 0o600)
+}
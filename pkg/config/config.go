@@ -0,0 +1,299 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/hezhizhen/sak/pkg/dryrun"
+	"github.com/hezhizhen/sak/pkg/exit"
+	"github.com/hezhizhen/sak/pkg/i18n"
+	"github.com/hezhizhen/sak/pkg/netinfo"
+	"github.com/hezhizhen/sak/pkg/output"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the content of the sak configuration file
+type Config struct {
+	Settings `yaml:",inline"`
+	// Profile is the profile used when none is selected with --profile
+	Profile string `yaml:"profile,omitempty"`
+	// Profiles holds named sets of settings overriding the top-level ones
+	Profiles map[string]Settings `yaml:"profiles,omitempty"`
+}
+
+// Settings are the configuration values that can be overridden per profile
+type Settings struct {
+	// Editor is the command used to edit files, overriding $VISUAL and $EDITOR
+	Editor string `yaml:"editor,omitempty"`
+	// History controls whether invocations are recorded for `sak recent`
+	History *bool `yaml:"history,omitempty"`
+	// Color is the color mode: auto, always or never
+	Color string `yaml:"color,omitempty"`
+	// UpdateNotice controls the daily check for newer sak releases
+	UpdateNotice *bool `yaml:"update-notice,omitempty"`
+	// Language is the language of the output: en or zh
+	Language string `yaml:"language,omitempty"`
+	// TableBorder draws borders around tables
+	TableBorder *bool `yaml:"table-border,omitempty"`
+	// Theme overrides the colors of the output roles
+	Theme Theme `yaml:"theme,omitempty"`
+	// ClipHistory is the number of texts copied with `sak clip` that are
+	// remembered, none by default
+	ClipHistory *int `yaml:"clip-history,omitempty"`
+	// WeatherCity is the city `sak weather` reports on when none is given
+	WeatherCity string `yaml:"weather-city,omitempty"`
+	// WeatherProvider is the service `sak weather` queries, open-meteo by
+	// default
+	WeatherProvider string `yaml:"weather-provider,omitempty"`
+	// WeatherKey is the API key of the weather provider
+	WeatherKey string `yaml:"weather-key,omitempty"`
+	// IPLookup is the URL `sak ip` fetches the public IP address from
+	IPLookup string `yaml:"ip-lookup,omitempty"`
+}
+
+// Theme holds the configured color of each output role, see
+// output.ParseColor for the accepted values
+type Theme struct {
+	Heading string `yaml:"heading,omitempty"`
+	Success string `yaml:"success,omitempty"`
+	Warn    string `yaml:"warn,omitempty"`
+	Error   string `yaml:"error,omitempty"`
+	Accent  string `yaml:"accent,omitempty"`
+}
+
+// field returns the setting of role in t
+func (t *Theme) field(role output.Role) *string {
+	switch role {
+	case output.Heading:
+		return &t.Heading
+	case output.Success:
+		return &t.Success
+	case output.Warn:
+		return &t.Warn
+	case output.Error:
+		return &t.Error
+	case output.Accent:
+		return &t.Accent
+	}
+	return nil
+}
+
+// OutputTheme returns the configured colors as an output.Theme. Roles that
+// are not set or hold an invalid color keep their default color.
+func (s *Settings) OutputTheme() output.Theme {
+	theme := output.Theme{}
+	for _, role := range output.Roles {
+		value := *s.Theme.field(role)
+		if value == "" {
+			continue
+		}
+		if color, err := output.ParseColor(value); err == nil {
+			theme[role] = color
+		}
+	}
+	return theme
+}
+
+// HistoryEnabled reports whether invocations should be recorded, which is
+// the default
+func (s *Settings) HistoryEnabled() bool {
+	return s.History == nil || *s.History
+}
+
+// UpdateNoticeEnabled reports whether a notice should be printed when a newer
+// release exists, which is the default
+func (s *Settings) UpdateNoticeEnabled() bool {
+	return s.UpdateNotice == nil || *s.UpdateNotice
+}
+
+// TableBorderEnabled reports whether tables are drawn with borders, which
+// they are not by default
+func (s *Settings) TableBorderEnabled() bool {
+	return s.TableBorder != nil && *s.TableBorder
+}
+
+// ClipHistorySize returns the number of copied texts to remember
+func (s *Settings) ClipHistorySize() int {
+	if s.ClipHistory == nil {
+		return 0
+	}
+	return *s.ClipHistory
+}
+
+// IPLookupURL returns the URL of the service telling the public IP address
+func (s *Settings) IPLookupURL() string {
+	if s.IPLookup == "" {
+		return netinfo.DefaultLookupURL
+	}
+	return s.IPLookup
+}
+
+// override returns s with every value set in o replacing its own
+func (s Settings) override(o Settings) Settings {
+	if o.Editor != "" {
+		s.Editor = o.Editor
+	}
+	if o.History != nil {
+		s.History = o.History
+	}
+	if o.Color != "" {
+		s.Color = o.Color
+	}
+	if o.UpdateNotice != nil {
+		s.UpdateNotice = o.UpdateNotice
+	}
+	if o.Language != "" {
+		s.Language = o.Language
+	}
+	if o.TableBorder != nil {
+		s.TableBorder = o.TableBorder
+	}
+	for _, role := range output.Roles {
+		if value := *o.Theme.field(role); value != "" {
+			*s.Theme.field(role) = value
+		}
+	}
+	if o.ClipHistory != nil {
+		s.ClipHistory = o.ClipHistory
+	}
+	if o.WeatherCity != "" {
+		s.WeatherCity = o.WeatherCity
+	}
+	if o.WeatherProvider != "" {
+		s.WeatherProvider = o.WeatherProvider
+	}
+	if o.WeatherKey != "" {
+		s.WeatherKey = o.WeatherKey
+	}
+	if o.IPLookup != "" {
+		s.IPLookup = o.IPLookup
+	}
+	return s
+}
+
+// Path returns the location of the configuration file: $SAK_CONFIG if set,
+// otherwise sak/config.yaml under $XDG_CONFIG_HOME or ~/.config
+func Path() (string, error) {
+	if path := os.Getenv("SAK_CONFIG"); path != "" {
+		return path, nil
+	}
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "sak", "config.yaml"), nil
+}
+
+// DataDir returns the directory holding the data files of sak commands, sak
+// under $XDG_DATA_HOME or ~/.local/share
+func DataDir() (string, error) {
+	dir := os.Getenv("XDG_DATA_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(dir, "sak"), nil
+}
+
+// StateDir returns the directory holding state kept between runs, such as
+// the invocation history, sak under $XDG_STATE_HOME or ~/.local/state
+func StateDir() (string, error) {
+	dir := os.Getenv("XDG_STATE_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, ".local", "state")
+	}
+	return filepath.Join(dir, "sak"), nil
+}
+
+// Load reads the configuration file, returning an empty configuration if it
+// does not exist yet. The settings of the active profile are applied on top
+// of the top-level ones.
+func Load() (*Config, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	c, err := LoadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return c.Resolve(c.ActiveProfile())
+}
+
+// ActiveProfile returns the profile selected with $SAK_PROFILE (set by the
+// global --profile flag), falling back to the default profile of c
+func (c *Config) ActiveProfile() string {
+	if profile := os.Getenv("SAK_PROFILE"); profile != "" {
+		return profile
+	}
+	return c.Profile
+}
+
+// Resolve returns a copy of c whose settings are overridden by the given
+// profile. An empty profile name leaves the settings unchanged.
+func (c *Config) Resolve(profile string) (*Config, error) {
+	resolved := *c
+	if profile == "" {
+		return &resolved, nil
+	}
+	settings, ok := c.Profiles[profile]
+	if !ok {
+		return nil, exit.New(exit.NotFound, i18n.Errorf("unknown profile %q", profile))
+	}
+	resolved.Settings = c.Settings.override(settings)
+	return &resolved, nil
+}
+
+// LoadFile reads and validates the configuration file at path. Unknown keys
+// are reported as errors so typos don't go unnoticed.
+func LoadFile(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Config{}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return nil, exit.New(exit.ParseError, i18n.Errorf("parse %s: %w", path, err))
+	}
+	return c, nil
+}
+
+// Save writes the configuration to path, creating its directory if needed
+func (c *Config) Save(ctx context.Context, path string) error {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(c); err != nil {
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+	data := buf.Bytes()
+	if err := dryrun.MkdirAll(ctx, filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return dryrun.WriteFile(ctx, path, data, 0o644)
+}
@@ -0,0 +1,221 @@
+package config
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hezhizhen/sak/pkg/exit"
+	"github.com/hezhizhen/sak/pkg/i18n"
+	"github.com/hezhizhen/sak/pkg/output"
+	"github.com/hezhizhen/sak/pkg/weather"
+)
+
+// Key is a configuration key that can be read and written from the CLI
+type Key struct {
+	Name        string
+	Description string
+	// Secret keys have their values left out of the invocation history
+	Secret bool
+
+	get func(s *Settings) string
+	set func(s *Settings, value string) error
+}
+
+// Get returns the value of the key in s
+func (k Key) Get(s *Settings) string {
+	return k.get(s)
+}
+
+// Set validates value and stores it in s
+func (k Key) Set(s *Settings, value string) error {
+	return k.set(s, value)
+}
+
+// Keys lists the known configuration keys
+var Keys = []Key{
+	{
+		Name:        "editor",
+		Description: "command used to edit files, overriding $VISUAL and $EDITOR",
+		get:         func(s *Settings) string { return s.Editor },
+		set: func(s *Settings, value string) error {
+			s.Editor = strings.TrimSpace(value)
+			return nil
+		},
+	},
+	{
+		Name:        "history",
+		Description: "record invocations for `sak recent` (true or false)",
+		get:         func(s *Settings) string { return strconv.FormatBool(s.HistoryEnabled()) },
+		set: func(s *Settings, value string) error {
+			enabled, err := strconv.ParseBool(value)
+			if err != nil {
+				return i18n.Errorf("expected true or false")
+			}
+			s.History = &enabled
+			return nil
+		},
+	},
+	{
+		Name:        "color",
+		Description: "colored output: auto, always or never",
+		get: func(s *Settings) string {
+			mode, _ := output.ParseColorMode(s.Color)
+			return mode
+		},
+		set: func(s *Settings, value string) error {
+			mode, err := output.ParseColorMode(value)
+			if err != nil {
+				return err
+			}
+			s.Color = mode
+			return nil
+		},
+	},
+	{
+		Name:        "update-notice",
+		Description: "print a notice when a newer release exists (true or false)",
+		get:         func(s *Settings) string { return strconv.FormatBool(s.UpdateNoticeEnabled()) },
+		set: func(s *Settings, value string) error {
+			enabled, err := strconv.ParseBool(value)
+			if err != nil {
+				return i18n.Errorf("expected true or false")
+			}
+			s.UpdateNotice = &enabled
+			return nil
+		},
+	},
+	{
+		Name:        "language",
+		Description: "language of the output: en or zh",
+		get: func(s *Settings) string {
+			language, _ := i18n.ParseLanguage(s.Language)
+			return string(language)
+		},
+		set: func(s *Settings, value string) error {
+			language, err := i18n.ParseLanguage(value)
+			if err != nil {
+				return err
+			}
+			s.Language = string(language)
+			return nil
+		},
+	},
+	{
+		Name:        "table-border",
+		Description: "draw borders around tables (true or false)",
+		get:         func(s *Settings) string { return strconv.FormatBool(s.TableBorderEnabled()) },
+		set: func(s *Settings, value string) error {
+			border, err := strconv.ParseBool(value)
+			if err != nil {
+				return i18n.Errorf("expected true or false")
+			}
+			s.TableBorder = &border
+			return nil
+		},
+	},
+	{
+		Name:        "clip-history",
+		Description: "number of texts copied with `sak clip` to remember, 0 to keep none",
+		get:         func(s *Settings) string { return strconv.Itoa(s.ClipHistorySize()) },
+		set: func(s *Settings, value string) error {
+			size, err := strconv.Atoi(value)
+			if err != nil || size < 0 {
+				return i18n.Errorf("expected a number of at least 0")
+			}
+			s.ClipHistory = &size
+			return nil
+		},
+	},
+	{
+		Name:        "weather-city",
+		Description: "city `sak weather` reports on when none is given",
+		get:         func(s *Settings) string { return s.WeatherCity },
+		set: func(s *Settings, value string) error {
+			s.WeatherCity = strings.TrimSpace(value)
+			return nil
+		},
+	},
+	{
+		Name:        "weather-provider",
+		Description: "service queried by `sak weather`: open-meteo or openweathermap",
+		get: func(s *Settings) string {
+			if s.WeatherProvider == "" {
+				return weather.DefaultProvider
+			}
+			return s.WeatherProvider
+		},
+		set: func(s *Settings, value string) error {
+			value = strings.ToLower(strings.TrimSpace(value))
+			if err := weather.CheckProvider(value); err != nil {
+				return err
+			}
+			s.WeatherProvider = value
+			return nil
+		},
+	},
+	{
+		Name:        "weather-key",
+		Description: "API key of the weather provider, required by openweathermap",
+		Secret:      true,
+		get:         func(s *Settings) string { return s.WeatherKey },
+		set: func(s *Settings, value string) error {
+			s.WeatherKey = strings.TrimSpace(value)
+			return nil
+		},
+	},
+	{
+		Name:        "ip-lookup",
+		Description: "URL of the service `sak ip` gets the public IP address from, answering it as plain text",
+		get:         func(s *Settings) string { return s.IPLookupURL() },
+		set: func(s *Settings, value string) error {
+			value = strings.TrimSpace(value)
+			if value == "default" {
+				value = ""
+			} else if u, err := url.Parse(value); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return i18n.Errorf("expected an http or https URL")
+			}
+			s.IPLookup = value
+			return nil
+		},
+	},
+	themeKey(output.Heading),
+	themeKey(output.Success),
+	themeKey(output.Warn),
+	themeKey(output.Error),
+	themeKey(output.Accent),
+}
+
+// themeKey is the key setting the color of an output role
+func themeKey(role output.Role) Key {
+	return Key{
+		Name:        "theme." + string(role),
+		Description: "color of " + string(role) + " output, e.g. green, bright-red, bold+cyan or none",
+		get: func(s *Settings) string {
+			if value := *s.Theme.field(role); value != "" {
+				return value
+			}
+			return "default"
+		},
+		set: func(s *Settings, value string) error {
+			value = strings.ToLower(strings.TrimSpace(value))
+			if value == "default" {
+				value = ""
+			} else if _, err := output.ParseColor(value); err != nil {
+				return err
+			}
+			*s.Theme.field(role) = value
+			return nil
+		},
+	}
+}
+
+// LookupKey finds a known key by name
+func LookupKey(name string) (Key, error) {
+	for _, k := range Keys {
+		if k.Name == name {
+			return k, nil
+		}
+	}
+	return Key{}, exit.New(exit.NotFound, i18n.Errorf("unknown config key %q", name))
+}
@@ -0,0 +1,333 @@
+package convert
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/hezhizhen/sak/pkg/exit"
+	"github.com/hezhizhen/sak/pkg/i18n"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Formats lists the supported formats
+var Formats = []string{"json", "toml", "yaml"}
+
+// FormatOf returns the format of a file name by its extension, or "" if
+// it is not one of Formats
+func FormatOf(name string) string {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json":
+		return "json"
+	case ".toml":
+		return "toml"
+	case ".yaml", ".yml":
+		return "yaml"
+	}
+	return ""
+}
+
+// CheckFormat returns a usage error if format is not supported
+func CheckFormat(format string) error {
+	for _, f := range Formats {
+		if f == format {
+			return nil
+		}
+	}
+	return exit.New(exit.Usage, i18n.Errorf("unknown format %q (expected yaml, json or toml)", format))
+}
+
+// Read parses the documents of data in format. The documents are returned
+// as YAML nodes, which keep the order of the keys.
+func Read(data []byte, format string) ([]*yaml.Node, error) {
+	var docs []*yaml.Node
+	var err error
+	switch format {
+	case "json":
+		docs, err = readJSON(data)
+	case "toml":
+		var doc *yaml.Node
+		doc, err = readTOML(data)
+		docs = []*yaml.Node{doc}
+	default:
+		docs, err = readYAML(data)
+	}
+	if err != nil {
+		return nil, exit.New(exit.ParseError, err)
+	}
+	return docs, nil
+}
+
+// Write writes docs to w in format
+func Write(w io.Writer, docs []*yaml.Node, format string) error {
+	switch format {
+	case "json":
+		for _, doc := range docs {
+			var buf bytes.Buffer
+			if err := writeJSON(&buf, doc); err != nil {
+				return err
+			}
+			var out bytes.Buffer
+			if err := json.Indent(&out, buf.Bytes(), "", "  "); err != nil {
+				return err
+			}
+			out.WriteByte('\n')
+			if _, err := out.WriteTo(w); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "toml":
+		if len(docs) != 1 {
+			return exit.New(exit.Usage, i18n.Errorf("TOML holds a single document, got %d", len(docs)))
+		}
+		return writeTOML(w, docs[0])
+	default:
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		for _, doc := range docs {
+			if err := enc.Encode(doc); err != nil {
+				return err
+			}
+		}
+		return enc.Close()
+	}
+}
+
+func readYAML(data []byte) ([]*yaml.Node, error) {
+	var docs []*yaml.Node
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var doc yaml.Node
+		err := dec.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			return docs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, &doc)
+	}
+}
+
+// readJSON builds the nodes token by token, as decoding into maps would lose
+// the order of the keys
+func readJSON(data []byte) ([]*yaml.Node, error) {
+	var docs []*yaml.Node
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	for {
+		n, err := jsonValue(dec)
+		if errors.Is(err, io.EOF) {
+			return docs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{n}})
+	}
+}
+
+func jsonValue(dec *json.Decoder) (*yaml.Node, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch v := tok.(type) {
+	case json.Delim:
+		n := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		if v == '{' {
+			n.Kind, n.Tag = yaml.MappingNode, "!!map"
+		}
+		for dec.More() {
+			if n.Kind == yaml.MappingNode {
+				key, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				n.Content = append(n.Content, scalar("!!str", key.(string)))
+			}
+			item, err := jsonValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			n.Content = append(n.Content, item)
+		}
+		// the closing delimiter
+		_, err := dec.Token()
+		return n, err
+	case string:
+		return scalar("!!str", v), nil
+	case json.Number:
+		if strings.ContainsAny(v.String(), ".eE") {
+			return scalar("!!float", v.String()), nil
+		}
+		return scalar("!!int", v.String()), nil
+	case bool:
+		return scalar("!!bool", strconv.FormatBool(v)), nil
+	default:
+		return scalar("!!null", "null"), nil
+	}
+}
+
+// scalar returns a scalar node. Strings that would be read as another type
+// if written plainly, such as yes by YAML 1.1 readers, are double-quoted as
+// yaml.Marshal does.
+func scalar(tag, value string) *yaml.Node {
+	n := &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value}
+	if tag == "!!str" {
+		var probe yaml.Node
+		if err := probe.Encode(value); err != nil || probe.Tag != "!!str" || probe.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) != 0 {
+			n.Style = yaml.DoubleQuotedStyle
+		}
+	}
+	return n
+}
+
+// merged returns mapping m with its merge keys (<<) expanded: the pairs of
+// the merged mappings take the place of the key, except those m sets
+// itself. Of a list of merged mappings, the first setting a key wins.
+func merged(m *yaml.Node) *yaml.Node {
+	own := map[string]bool{}
+	hasMerge := false
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if isMergeKey(m.Content[i]) {
+			hasMerge = true
+		} else {
+			own[m.Content[i].Value] = true
+		}
+	}
+	if !hasMerge {
+		return m
+	}
+
+	out := &yaml.Node{Kind: yaml.MappingNode, Tag: m.Tag, Style: m.Style}
+	seen := map[string]bool{}
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		key, value := m.Content[i], m.Content[i+1]
+		if !isMergeKey(key) {
+			out.Content = append(out.Content, key, value)
+			continue
+		}
+		sources := []*yaml.Node{value}
+		if v := resolve(value); v != nil && v.Kind == yaml.SequenceNode {
+			sources = v.Content
+		}
+		for _, source := range sources {
+			source = resolve(source)
+			if source == nil || source.Kind != yaml.MappingNode {
+				continue
+			}
+			for j := 0; j+1 < len(source.Content); j += 2 {
+				name := source.Content[j].Value
+				if own[name] || seen[name] {
+					continue
+				}
+				seen[name] = true
+				out.Content = append(out.Content, source.Content[j], source.Content[j+1])
+			}
+		}
+	}
+	return out
+}
+
+func isMergeKey(n *yaml.Node) bool {
+	return n.Kind == yaml.ScalarNode && n.ShortTag() == "!!merge"
+}
+
+func writeJSON(buf *bytes.Buffer, n *yaml.Node) error {
+	switch n.Kind {
+	case yaml.DocumentNode:
+		if len(n.Content) == 0 {
+			buf.WriteString("null")
+			return nil
+		}
+		return writeJSON(buf, n.Content[0])
+	case yaml.AliasNode:
+		return writeJSON(buf, n.Alias)
+	case yaml.MappingNode:
+		n = merged(n)
+		buf.WriteByte('{')
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeString(buf, n.Content[i].Value)
+			buf.WriteByte(':')
+			if err := writeJSON(buf, n.Content[i+1]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+		return nil
+	case yaml.SequenceNode:
+		buf.WriteByte('[')
+		for i, item := range n.Content {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeJSON(buf, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+		return nil
+	}
+
+	switch n.ShortTag() {
+	case "!!null":
+		buf.WriteString("null")
+	case "!!bool":
+		var b bool
+		if err := n.Decode(&b); err != nil {
+			return invalidValue(n)
+		}
+		buf.WriteString(strconv.FormatBool(b))
+	case "!!int":
+		var i int64
+		if err := n.Decode(&i); err != nil {
+			// too large for int64, but still a valid JSON number
+			buf.WriteString(strings.ReplaceAll(n.Value, "_", ""))
+			return nil
+		}
+		buf.WriteString(strconv.FormatInt(i, 10))
+	case "!!float":
+		var f float64
+		if err := n.Decode(&f); err != nil {
+			return invalidValue(n)
+		}
+		if math.IsInf(f, 0) || math.IsNaN(f) {
+			return exit.New(exit.Usage, i18n.Errorf("%s cannot be represented in JSON", n.Value))
+		}
+		// keep the notation of the input, such as 1.0, if it is valid JSON
+		if json.Valid([]byte(n.Value)) {
+			buf.WriteString(n.Value)
+		} else {
+			buf.WriteString(strconv.FormatFloat(f, 'g', -1, 64))
+		}
+	default:
+		writeString(buf, n.Value)
+	}
+	return nil
+}
+
+// invalidValue is the error of a scalar that does not hold a value of its
+// type, such as a float out of range
+func invalidValue(n *yaml.Node) error {
+	return exit.New(exit.ParseError, i18n.Errorf("invalid %s value %s", strings.TrimPrefix(n.ShortTag(), "!!"), n.Value))
+}
+
+func writeString(buf *bytes.Buffer, s string) {
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	// Encode only fails for unsupported types
+	_ = enc.Encode(s)
+	// drop the line break added by Encode
+	buf.Truncate(buf.Len() - 1)
+}
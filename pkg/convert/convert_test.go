@@ -0,0 +1,195 @@
+package convert
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hezhizhen/sak/pkg/exit"
+)
+
+func convert(t *testing.T, input, from, to string) (string, error) {
+	t.Helper()
+	docs, err := Read([]byte(input), from)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	err = Write(&buf, docs, to)
+	return buf.String(), err
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		from, to string
+		want     string
+	}{
+		{
+			name:  "json keeps key order",
+			input: `{"b": 1, "a": [true, null, 1.5, "x"]}`,
+			from:  "json", to: "json",
+			want: "{\n  \"b\": 1,\n  \"a\": [\n    true,\n    null,\n    1.5,\n    \"x\"\n  ]\n}\n",
+		},
+		{
+			name:  "json strings that look like other types are quoted in yaml",
+			input: `{"a": "yes", "b": "1.0", "c": "null", "d": "<<", "e": "plain", "f": ""}`,
+			from:  "json", to: "yaml",
+			want: "a: \"yes\"\nb: \"1.0\"\nc: \"null\"\nd: \"<<\"\ne: plain\nf: \"\"\n",
+		},
+		{
+			name:  "yaml merge keys are expanded in json",
+			input: "base: &b {x: 1, y: 1}\nfoo: {<<: *b, y: 2}\n",
+			from:  "yaml", to: "json",
+			want: "{\n  \"base\": {\n    \"x\": 1,\n    \"y\": 1\n  },\n  \"foo\": {\n    \"x\": 1,\n    \"y\": 2\n  }\n}\n",
+		},
+		{
+			name:  "the first of a list of merged mappings wins",
+			input: "a: &a {x: 1}\nb: &b {x: 2, z: 2}\nc: {<<: [*a, *b]}\n",
+			from:  "yaml", to: "json",
+			want: "{\n  \"a\": {\n    \"x\": 1\n  },\n  \"b\": {\n    \"x\": 2,\n    \"z\": 2\n  },\n  \"c\": {\n    \"x\": 1,\n    \"z\": 2\n  }\n}\n",
+		},
+		{
+			name:  "yaml merge keys are expanded in toml",
+			input: "base: &b {x: 1}\nfoo: {<<: *b, y: 2}\n",
+			from:  "yaml", to: "toml",
+			want: "[base]\nx = 1\n\n[foo]\nx = 1\ny = 2\n",
+		},
+		{
+			name:  "toml tables follow plain values",
+			input: "server:\n  host: localhost\ntitle: x\n",
+			from:  "yaml", to: "toml",
+			want: "title = \"x\"\n\n[server]\nhost = \"localhost\"\n",
+		},
+		{
+			name: "toml to json",
+			input: `title = "demo"
+count = 1_000
+ratio = +0.5
+hex = 0xff
+when = 1979-05-27T07:32:00Z
+list = [1, 2, 3]
+point = { x = 1, "y z" = 2 }
+
+[owner]
+name = 'Tom'
+
+[[items]]
+id = 1
+
+[[items]]
+id = 2
+`,
+			from: "toml", to: "json",
+			want: `{
+  "title": "demo",
+  "count": 1000,
+  "ratio": 0.5,
+  "hex": 255,
+  "when": "1979-05-27T07:32:00Z",
+  "list": [
+    1,
+    2,
+    3
+  ],
+  "point": {
+    "x": 1,
+    "y z": 2
+  },
+  "owner": {
+    "name": "Tom"
+  },
+  "items": [
+    {
+      "id": 1
+    },
+    {
+      "id": 2
+    }
+  ]
+}
+`,
+		},
+		{
+			name:  "toml round trip",
+			input: "a = 1\nb = \"two\"\nc = [1.0, 2.5]\nd = true\n\n[e]\nf = \"g\"\n\n[[h]]\ni = 1\n",
+			from:  "toml", to: "toml",
+			want: "a = 1\nb = \"two\"\nc = [1.0, 2.5]\nd = true\n\n[e]\nf = \"g\"\n\n[[h]]\ni = 1\n",
+		},
+		{
+			name:  "toml strings",
+			input: "a = \"tab\\there\"\nb = '''\nraw \\n'''\nc = \"\"\"\nx \\\n  y\"\"\"\n",
+			from:  "toml", to: "json",
+			want: "{\n  \"a\": \"tab\\there\",\n  \"b\": \"raw \\\\n\",\n  \"c\": \"x y\"\n}\n",
+		},
+		{
+			name:  "toml strings that look like other types are quoted in yaml",
+			input: "a = \"on\"\nb = \"12:30\"\n",
+			from:  "toml", to: "yaml",
+			want: "a: \"on\"\nb: \"12:30\"\n",
+		},
+		{
+			name:  "yaml multiple documents to json",
+			input: "a: 1\n---\nb: 2\n",
+			from:  "yaml", to: "json",
+			want: "{\n  \"a\": 1\n}\n{\n  \"b\": 2\n}\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := convert(t, tt.input, tt.from, tt.to)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		from, to string
+		code     int
+	}{
+		{"float out of range in toml", "c = 1e400\n", "toml", "json", exit.ParseError},
+		{"integer out of range in toml", "c = 9223372036854775808\n", "toml", "json", exit.ParseError},
+		{"duplicate toml key", "a = 1\na = 2\n", "toml", "json", exit.ParseError},
+		{"unterminated toml string", "a = \"x\n", "toml", "json", exit.ParseError},
+		{"invalid json", `{"a": }`, "json", "yaml", exit.ParseError},
+		{"float out of range in yaml", "c: !!float 1e400\n", "yaml", "json", exit.ParseError},
+		{"infinity in json", "c: .inf\n", "yaml", "json", exit.Usage},
+		{"null in toml", "c: null\n", "yaml", "toml", exit.Usage},
+		{"toml needs a mapping", "[1, 2]\n", "yaml", "toml", exit.Usage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := convert(t, tt.input, tt.from, tt.to)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if code := exit.Code(err); code != tt.code {
+				t.Errorf("got exit code %d (%v), want %d", code, err, tt.code)
+			}
+		})
+	}
+}
+
+func TestFormatOf(t *testing.T) {
+	tests := map[string]string{
+		"a.json":   "json",
+		"a.YML":    "yaml",
+		"a.yaml":   "yaml",
+		"a.toml":   "toml",
+		"a.txt":    "",
+		"Makefile": "",
+	}
+	for name, want := range tests {
+		if got := FormatOf(name); got != want {
+			t.Errorf("FormatOf(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
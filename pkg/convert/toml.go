@@ -0,0 +1,805 @@
+package convert
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hezhizhen/sak/pkg/exit"
+	"github.com/hezhizhen/sak/pkg/i18n"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	bareKeyPattern  = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+	decimalPattern  = regexp.MustCompile(`^[+-]?(0|[1-9](_?[0-9])*)$`)
+	prefixedPattern = regexp.MustCompile(`^0(x[0-9A-Fa-f](_?[0-9A-Fa-f])*|o[0-7](_?[0-7])*|b[01](_?[01])*)$`)
+	floatPattern    = regexp.MustCompile(`^[+-]?(0|[1-9](_?[0-9])*)(\.[0-9](_?[0-9])*)?([eE][+-]?[0-9](_?[0-9])*)?$`)
+	datePattern     = regexp.MustCompile(`^[0-9]{4}-[0-9]{2}-[0-9]{2}$`)
+	dateTimePattern = regexp.MustCompile(`^[0-9]{4}-[0-9]{2}-[0-9]{2}([Tt ][0-9]{2}:[0-9]{2}:[0-9]{2}(\.[0-9]+)?([Zz]|[+-][0-9]{2}:[0-9]{2})?)?$`)
+	timePattern     = regexp.MustCompile(`^[0-9]{2}:[0-9]{2}:[0-9]{2}(\.[0-9]+)?$`)
+)
+
+// tomlParser reads TOML into YAML nodes so the order of the keys is kept.
+// Redefining a table is not reported, otherwise it follows TOML 1.0.
+type tomlParser struct {
+	src  string
+	pos  int
+	line int
+}
+
+func readTOML(data []byte) (*yaml.Node, error) {
+	p := &tomlParser{src: string(data), line: 1}
+	root := mapping()
+	if err := p.parse(root); err != nil {
+		return nil, i18n.Errorf("line %d: %w", p.line, err)
+	}
+	return &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{root}}, nil
+}
+
+func (p *tomlParser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *tomlParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+func (p *tomlParser) rest() string {
+	return p.src[p.pos:]
+}
+
+// skipSpace skips spaces and tabs and a comment up to the end of the line
+func (p *tomlParser) skipSpace() {
+	for !p.eof() && (p.peek() == ' ' || p.peek() == '\t') {
+		p.pos++
+	}
+	if p.peek() == '#' {
+		for !p.eof() && p.peek() != '\n' {
+			p.pos++
+		}
+	}
+}
+
+// skipBlank also skips line breaks
+func (p *tomlParser) skipBlank() {
+	for {
+		p.skipSpace()
+		switch p.peek() {
+		case '\n':
+			p.line++
+			p.pos++
+		case '\r':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+// endLine expects nothing but a comment up to the end of the line
+func (p *tomlParser) endLine() error {
+	p.skipSpace()
+	if strings.HasPrefix(p.rest(), "\r\n") {
+		p.pos++
+	}
+	switch {
+	case p.eof():
+		return nil
+	case p.peek() == '\n':
+		p.line++
+		p.pos++
+		return nil
+	}
+	return p.unexpected()
+}
+
+func (p *tomlParser) unexpected() error {
+	if p.eof() {
+		return i18n.Errorf("unexpected end of file")
+	}
+	return i18n.Errorf("unexpected %q", p.peek())
+}
+
+func (p *tomlParser) expect(s string) error {
+	p.skipSpace()
+	if !strings.HasPrefix(p.rest(), s) {
+		return i18n.Errorf("expected %s", s)
+	}
+	p.pos += len(s)
+	return nil
+}
+
+func (p *tomlParser) parse(root *yaml.Node) error {
+	current := root
+	for {
+		p.skipBlank()
+		if p.eof() {
+			return nil
+		}
+
+		switch {
+		case strings.HasPrefix(p.rest(), "[["):
+			p.pos += 2
+			keys, err := p.key()
+			if err != nil {
+				return err
+			}
+			if err := p.expect("]]"); err != nil {
+				return err
+			}
+			parent, err := table(root, keys[:len(keys)-1])
+			if err != nil {
+				return err
+			}
+			last := keys[len(keys)-1]
+			array := lookup(parent, last)
+			if array == nil {
+				array = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+				appendPair(parent, last, array)
+			} else if array.Kind != yaml.SequenceNode {
+				return i18n.Errorf("%s is not an array of tables", strings.Join(keys, "."))
+			}
+			current = mapping()
+			array.Content = append(array.Content, current)
+		case p.peek() == '[':
+			p.pos++
+			keys, err := p.key()
+			if err != nil {
+				return err
+			}
+			if err := p.expect("]"); err != nil {
+				return err
+			}
+			if current, err = table(root, keys); err != nil {
+				return err
+			}
+		default:
+			if err := p.keyValue(current); err != nil {
+				return err
+			}
+		}
+		if err := p.endLine(); err != nil {
+			return err
+		}
+	}
+}
+
+// keyValue parses a key/value pair into t
+func (p *tomlParser) keyValue(t *yaml.Node) error {
+	keys, err := p.key()
+	if err != nil {
+		return err
+	}
+	if err := p.expect("="); err != nil {
+		return err
+	}
+	p.skipSpace()
+	value, err := p.value()
+	if err != nil {
+		return err
+	}
+	parent, err := table(t, keys[:len(keys)-1])
+	if err != nil {
+		return err
+	}
+	last := keys[len(keys)-1]
+	if lookup(parent, last) != nil {
+		return i18n.Errorf("duplicate key %s", strings.Join(keys, "."))
+	}
+	appendPair(parent, last, value)
+	return nil
+}
+
+// key parses a dotted key
+func (p *tomlParser) key() ([]string, error) {
+	var keys []string
+	for {
+		p.skipSpace()
+		var (
+			key string
+			err error
+		)
+		switch p.peek() {
+		case '"':
+			key, err = p.basicString()
+		case '\'':
+			key, err = p.literalString()
+		default:
+			start := p.pos
+			for !p.eof() && bareKeyPattern.MatchString(p.src[p.pos:p.pos+1]) {
+				p.pos++
+			}
+			if p.pos == start {
+				return nil, i18n.Errorf("expected a key")
+			}
+			key = p.src[start:p.pos]
+		}
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+
+		p.skipSpace()
+		if p.peek() != '.' {
+			return keys, nil
+		}
+		p.pos++
+	}
+}
+
+func (p *tomlParser) value() (*yaml.Node, error) {
+	line := p.line
+	var (
+		n   *yaml.Node
+		s   string
+		err error
+	)
+	switch {
+	case strings.HasPrefix(p.rest(), `"""`):
+		s, err = p.multilineBasicString()
+		n = scalar("!!str", s)
+	case p.peek() == '"':
+		s, err = p.basicString()
+		n = scalar("!!str", s)
+	case strings.HasPrefix(p.rest(), "'''"):
+		s, err = p.multilineLiteralString()
+		n = scalar("!!str", s)
+	case p.peek() == '\'':
+		s, err = p.literalString()
+		n = scalar("!!str", s)
+	case p.peek() == '[':
+		n, err = p.array()
+	case p.peek() == '{':
+		n, err = p.inlineTable()
+	default:
+		n, err = p.literal()
+	}
+	if err != nil {
+		return nil, err
+	}
+	n.Line = line
+	return n, nil
+}
+
+func (p *tomlParser) array() (*yaml.Node, error) {
+	p.pos++
+	n := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	for {
+		p.skipBlank()
+		if p.peek() == ']' {
+			p.pos++
+			return n, nil
+		}
+		item, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		n.Content = append(n.Content, item)
+
+		p.skipBlank()
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case ']':
+			p.pos++
+			return n, nil
+		default:
+			return nil, p.unexpected()
+		}
+	}
+}
+
+func (p *tomlParser) inlineTable() (*yaml.Node, error) {
+	p.pos++
+	n := mapping()
+	p.skipSpace()
+	if p.peek() == '}' {
+		p.pos++
+		return n, nil
+	}
+	for {
+		if err := p.keyValue(n); err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case '}':
+			p.pos++
+			return n, nil
+		default:
+			return nil, p.unexpected()
+		}
+	}
+}
+
+func (p *tomlParser) basicString() (string, error) {
+	p.pos++
+	var b strings.Builder
+	for {
+		switch c := p.peek(); {
+		case p.eof() || c == '\n':
+			return "", i18n.Errorf("unterminated string")
+		case c == '"':
+			p.pos++
+			return b.String(), nil
+		case c == '\\':
+			if err := p.escape(&b); err != nil {
+				return "", err
+			}
+		default:
+			b.WriteByte(c)
+			p.pos++
+		}
+	}
+}
+
+func (p *tomlParser) multilineBasicString() (string, error) {
+	p.pos += 3
+	p.skipFirstLineBreak()
+	var b strings.Builder
+	for {
+		switch c := p.peek(); {
+		case p.eof():
+			return "", i18n.Errorf("unterminated string")
+		case strings.HasPrefix(p.rest(), `"""`):
+			return p.closeMultiline(&b, '"'), nil
+		case c == '\\':
+			// a backslash at the end of a line trims the following whitespace
+			if rest := strings.TrimLeft(p.rest()[1:], " \t"); strings.HasPrefix(rest, "\n") || strings.HasPrefix(rest, "\r\n") {
+				p.pos++
+				for !p.eof() && strings.IndexByte(" \t\r\n", p.peek()) >= 0 {
+					if p.peek() == '\n' {
+						p.line++
+					}
+					p.pos++
+				}
+				continue
+			}
+			if err := p.escape(&b); err != nil {
+				return "", err
+			}
+		default:
+			if c == '\n' {
+				p.line++
+			}
+			b.WriteByte(c)
+			p.pos++
+		}
+	}
+}
+
+func (p *tomlParser) literalString() (string, error) {
+	p.pos++
+	end := strings.IndexAny(p.rest(), "'\n")
+	if end < 0 || p.rest()[end] == '\n' {
+		return "", i18n.Errorf("unterminated string")
+	}
+	s := p.rest()[:end]
+	p.pos += end + 1
+	return s, nil
+}
+
+func (p *tomlParser) multilineLiteralString() (string, error) {
+	p.pos += 3
+	p.skipFirstLineBreak()
+	end := strings.Index(p.rest(), "'''")
+	if end < 0 {
+		return "", i18n.Errorf("unterminated string")
+	}
+	var b strings.Builder
+	b.WriteString(p.rest()[:end])
+	p.line += strings.Count(p.rest()[:end], "\n")
+	p.pos += end
+	return p.closeMultiline(&b, '\''), nil
+}
+
+// skipFirstLineBreak skips a line break right after the opening delimiter
+// of a multi-line string
+func (p *tomlParser) skipFirstLineBreak() {
+	if strings.HasPrefix(p.rest(), "\r\n") {
+		p.pos++
+	}
+	if p.peek() == '\n' {
+		p.line++
+		p.pos++
+	}
+}
+
+// closeMultiline consumes the closing delimiter of a multi-line string, of
+// which up to two more quotes belong to the string
+func (p *tomlParser) closeMultiline(b *strings.Builder, quote byte) string {
+	n := 0
+	for p.pos+n < len(p.src) && p.src[p.pos+n] == quote && n < 5 {
+		n++
+	}
+	b.WriteString(strings.Repeat(string(quote), n-3))
+	p.pos += n
+	return b.String()
+}
+
+func (p *tomlParser) escape(b *strings.Builder) error {
+	p.pos++
+	c := p.peek()
+	p.pos++
+	switch c {
+	case 'b':
+		b.WriteByte('\b')
+	case 't':
+		b.WriteByte('\t')
+	case 'n':
+		b.WriteByte('\n')
+	case 'f':
+		b.WriteByte('\f')
+	case 'r':
+		b.WriteByte('\r')
+	case '"', '\\':
+		b.WriteByte(c)
+	case 'u', 'U':
+		size := 4
+		if c == 'U' {
+			size = 8
+		}
+		if p.pos+size > len(p.src) {
+			return i18n.Errorf("invalid escape \\%c", c)
+		}
+		r, err := strconv.ParseUint(p.src[p.pos:p.pos+size], 16, 32)
+		if err != nil {
+			return i18n.Errorf("invalid escape \\%c%s", c, p.src[p.pos:p.pos+size])
+		}
+		b.WriteRune(rune(r))
+		p.pos += size
+	default:
+		return i18n.Errorf("invalid escape \\%c", c)
+	}
+	return nil
+}
+
+// literal parses a boolean, number or date
+func (p *tomlParser) literal() (*yaml.Node, error) {
+	token := p.token()
+	// the date and time of a date-time may be separated by a space
+	if datePattern.MatchString(token) && len(p.rest()) > 2 && p.peek() == ' ' && isDigit(p.rest()[1]) && isDigit(p.rest()[2]) {
+		p.pos++
+		token += "T" + p.token()
+	}
+
+	switch {
+	case token == "":
+		return nil, p.unexpected()
+	case token == "true" || token == "false":
+		return scalar("!!bool", token), nil
+	case strings.TrimLeft(token, "+-") == "inf":
+		return scalar("!!float", strings.TrimPrefix(strings.Replace(token, "inf", ".inf", 1), "+")), nil
+	case strings.TrimLeft(token, "+-") == "nan":
+		return scalar("!!float", ".nan"), nil
+	case decimalPattern.MatchString(token):
+		i, err := strconv.ParseInt(strings.ReplaceAll(token, "_", ""), 10, 64)
+		if err != nil {
+			return nil, i18n.Errorf("invalid integer %s", token)
+		}
+		return scalar("!!int", strconv.FormatInt(i, 10)), nil
+	case prefixedPattern.MatchString(token):
+		i, err := strconv.ParseInt(token, 0, 64)
+		if err != nil {
+			return nil, i18n.Errorf("invalid integer %s", token)
+		}
+		return scalar("!!int", strconv.FormatInt(i, 10)), nil
+	case floatPattern.MatchString(token):
+		value := strings.TrimPrefix(strings.ReplaceAll(token, "_", ""), "+")
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return nil, i18n.Errorf("invalid float %s", token)
+		}
+		return scalar("!!float", value), nil
+	case dateTimePattern.MatchString(token):
+		return scalar("!!timestamp", token), nil
+	case timePattern.MatchString(token):
+		return scalar("!!str", token), nil
+	}
+	return nil, i18n.Errorf("invalid value %q", token)
+}
+
+// token returns the characters up to the next delimiter
+func (p *tomlParser) token() string {
+	start := p.pos
+	for !p.eof() && strings.IndexByte(" \t\r\n,]}#", p.peek()) < 0 {
+		p.pos++
+	}
+	return p.src[start:p.pos]
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func mapping() *yaml.Node {
+	return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+}
+
+// lookup returns the value of key in the mapping m, or nil
+func lookup(m *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func appendPair(m *yaml.Node, key string, value *yaml.Node) {
+	m.Content = append(m.Content, scalar("!!str", key), value)
+}
+
+// table returns the table at keys below t, creating missing ones. Keys
+// naming an array of tables continue in its last table.
+func table(t *yaml.Node, keys []string) (*yaml.Node, error) {
+	for i, key := range keys {
+		next := lookup(t, key)
+		switch {
+		case next == nil:
+			next = mapping()
+			appendPair(t, key, next)
+		case next.Kind == yaml.SequenceNode && len(next.Content) > 0 && next.Content[len(next.Content)-1].Kind == yaml.MappingNode:
+			next = next.Content[len(next.Content)-1]
+		case next.Kind != yaml.MappingNode:
+			return nil, i18n.Errorf("%s is not a table", strings.Join(keys[:i+1], "."))
+		}
+		t = next
+	}
+	return t, nil
+}
+
+// writeTOML writes doc, which must hold a mapping, as TOML. The keys keep
+// their order, except that tables follow the plain values of their parent
+// as TOML requires.
+func writeTOML(w io.Writer, doc *yaml.Node) error {
+	root := resolve(doc)
+	if root == nil || root.Kind != yaml.MappingNode {
+		return exit.New(exit.Usage, i18n.Errorf("TOML documents must be a mapping at the top level"))
+	}
+	var buf bytes.Buffer
+	if err := writeTable(&buf, nil, root); err != nil {
+		return err
+	}
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// resolve follows documents and aliases to the node holding the value, with
+// the merge keys of mappings expanded
+func resolve(n *yaml.Node) *yaml.Node {
+	for n != nil {
+		switch n.Kind {
+		case yaml.DocumentNode:
+			if len(n.Content) == 0 {
+				return nil
+			}
+			n = n.Content[0]
+		case yaml.AliasNode:
+			n = n.Alias
+		case yaml.MappingNode:
+			return merged(n)
+		default:
+			return n
+		}
+	}
+	return nil
+}
+
+// isTableArray reports whether n is a non-empty list of mappings, which is
+// written as an array of tables
+func isTableArray(n *yaml.Node) bool {
+	if n.Kind != yaml.SequenceNode || len(n.Content) == 0 {
+		return false
+	}
+	for _, item := range n.Content {
+		if resolve(item).Kind != yaml.MappingNode {
+			return false
+		}
+	}
+	return true
+}
+
+func writeTable(buf *bytes.Buffer, path []string, t *yaml.Node) error {
+	var tables, arrays []int
+	for i := 0; i+1 < len(t.Content); i += 2 {
+		key, value := t.Content[i].Value, resolve(t.Content[i+1])
+		switch {
+		case value.Kind == yaml.MappingNode:
+			tables = append(tables, i)
+		case isTableArray(value):
+			arrays = append(arrays, i)
+		default:
+			buf.WriteString(tomlKey(key) + " = ")
+			if err := writeInline(buf, append(path[:len(path):len(path)], key), value); err != nil {
+				return err
+			}
+			buf.WriteByte('\n')
+		}
+	}
+
+	for _, i := range tables {
+		sub := append(path[:len(path):len(path)], t.Content[i].Value)
+		value := resolve(t.Content[i+1])
+		// tables holding nothing but tables are defined by those
+		if len(value.Content) == 0 || hasPlainValues(value) {
+			writeHeader(buf, "["+tomlPath(sub)+"]")
+		}
+		if err := writeTable(buf, sub, value); err != nil {
+			return err
+		}
+	}
+	for _, i := range arrays {
+		sub := append(path[:len(path):len(path)], t.Content[i].Value)
+		for _, item := range resolve(t.Content[i+1]).Content {
+			writeHeader(buf, "[["+tomlPath(sub)+"]]")
+			if err := writeTable(buf, sub, resolve(item)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func writeHeader(buf *bytes.Buffer, header string) {
+	if buf.Len() > 0 {
+		buf.WriteByte('\n')
+	}
+	buf.WriteString(header + "\n")
+}
+
+func hasPlainValues(t *yaml.Node) bool {
+	for i := 1; i < len(t.Content); i += 2 {
+		if value := resolve(t.Content[i]); value.Kind != yaml.MappingNode && !isTableArray(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func writeInline(buf *bytes.Buffer, path []string, n *yaml.Node) error {
+	n = resolve(n)
+	if n == nil {
+		return exit.New(exit.Usage, i18n.Errorf("%s is empty, which TOML cannot represent", tomlPath(path)))
+	}
+	switch n.Kind {
+	case yaml.MappingNode:
+		buf.WriteString("{")
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			if i > 0 {
+				buf.WriteString(",")
+			}
+			key := n.Content[i].Value
+			buf.WriteString(" " + tomlKey(key) + " = ")
+			if err := writeInline(buf, append(path[:len(path):len(path)], key), n.Content[i+1]); err != nil {
+				return err
+			}
+		}
+		if len(n.Content) > 0 {
+			buf.WriteString(" ")
+		}
+		buf.WriteString("}")
+		return nil
+	case yaml.SequenceNode:
+		buf.WriteString("[")
+		for i, item := range n.Content {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			if err := writeInline(buf, path, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteString("]")
+		return nil
+	}
+
+	switch n.ShortTag() {
+	case "!!null":
+		return exit.New(exit.Usage, i18n.Errorf("%s is null, which TOML cannot represent", tomlPath(path)))
+	case "!!bool":
+		var b bool
+		if err := n.Decode(&b); err != nil {
+			return invalidValue(n)
+		}
+		buf.WriteString(strconv.FormatBool(b))
+	case "!!int":
+		var i int64
+		if err := n.Decode(&i); err != nil {
+			return invalidValue(n)
+		}
+		buf.WriteString(strconv.FormatInt(i, 10))
+	case "!!float":
+		var f float64
+		if err := n.Decode(&f); err != nil {
+			return invalidValue(n)
+		}
+		buf.WriteString(tomlFloat(f))
+	case "!!timestamp":
+		var t time.Time
+		switch {
+		case dateTimePattern.MatchString(n.Value):
+			buf.WriteString(n.Value)
+		case n.Decode(&t) == nil:
+			buf.WriteString(t.Format(time.RFC3339Nano))
+		default:
+			buf.WriteString(tomlString(n.Value))
+		}
+	default:
+		buf.WriteString(tomlString(n.Value))
+	}
+	return nil
+}
+
+func tomlFloat(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "inf"
+	case math.IsInf(f, -1):
+		return "-inf"
+	case math.IsNaN(f):
+		return "nan"
+	}
+	s := strconv.FormatFloat(f, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".e") {
+		s += ".0"
+	}
+	return s
+}
+
+func tomlKey(key string) string {
+	if bareKeyPattern.MatchString(key) {
+		return key
+	}
+	return tomlString(key)
+}
+
+func tomlPath(path []string) string {
+	keys := make([]string, len(path))
+	for i, key := range path {
+		keys[i] = tomlKey(key)
+	}
+	return strings.Join(keys, ".")
+}
+
+func tomlString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
@@ -0,0 +1,83 @@
+package dryrun
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/hezhizhen/sak/pkg/i18n"
+	"github.com/hezhizhen/sak/pkg/utils"
+)
+
+type contextKey struct{}
+
+// WithDryRun returns a context in which mutating actions performed through
+// this package are only reported to w instead of being carried out
+func WithDryRun(ctx context.Context, w io.Writer) context.Context {
+	return context.WithValue(ctx, contextKey{}, w)
+}
+
+// Enabled reports whether ctx is in dry-run mode
+func Enabled(ctx context.Context) bool {
+	_, ok := ctx.Value(contextKey{}).(io.Writer)
+	return ok
+}
+
+// Report prints a description of a skipped action in dry-run mode, and does
+// nothing otherwise
+func Report(ctx context.Context, format string, args ...interface{}) {
+	if w, ok := ctx.Value(contextKey{}).(io.Writer); ok {
+		fmt.Fprintln(w, i18n.T("[dry-run] would")+" "+i18n.Sprintf(format, args...))
+	}
+}
+
+// Do runs action, or only reports description in dry-run mode
+func Do(ctx context.Context, description string, action func() error) error {
+	if Enabled(ctx) {
+		Report(ctx, "%s", description)
+		return nil
+	}
+	return action()
+}
+
+// WriteFile writes the file with utils.WriteFileAtomic, reporting whether it
+// would be created or overwritten in dry-run mode
+func WriteFile(ctx context.Context, path string, data []byte, perm os.FileMode) error {
+	if Enabled(ctx) {
+		_, err := os.Stat(path)
+		switch {
+		case err == nil:
+			Report(ctx, "overwrite %s (%d bytes)", path, len(data))
+		case errors.Is(err, os.ErrNotExist):
+			Report(ctx, "create %s (%d bytes)", path, len(data))
+		default:
+			return err
+		}
+		return nil
+	}
+	return utils.WriteFileAtomic(path, data, perm)
+}
+
+// MkdirAll is os.MkdirAll, reporting missing directories in dry-run mode
+func MkdirAll(ctx context.Context, path string, perm os.FileMode) error {
+	if Enabled(ctx) {
+		if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+			Report(ctx, "create directory %s", path)
+		}
+		return nil
+	}
+	return os.MkdirAll(path, perm)
+}
+
+// Run runs cmd, or only reports its command line in dry-run mode
+func Run(ctx context.Context, cmd *exec.Cmd) error {
+	if Enabled(ctx) {
+		Report(ctx, "run %s", strings.Join(cmd.Args, " "))
+		return nil
+	}
+	return cmd.Run()
+}
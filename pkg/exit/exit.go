@@ -0,0 +1,72 @@
+package exit
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+)
+
+// Exit codes of sak
+const (
+	OK          = 0
+	Failure     = 1
+	Usage       = 2
+	NotFound    = 3
+	ParseError  = 4
+	ToolMissing = 5
+	Aborted     = 6
+)
+
+var kinds = map[int]string{
+	OK:          "ok",
+	Failure:     "error",
+	Usage:       "usage",
+	NotFound:    "not_found",
+	ParseError:  "parse_error",
+	ToolMissing: "tool_missing",
+	Aborted:     "aborted",
+}
+
+// Error is an error carrying the code sak should exit with
+type Error struct {
+	Code int
+	Err  error
+}
+
+// New wraps err so that sak exits with code
+func New(code int, err error) error {
+	return &Error{Code: code, Err: err}
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Code returns the exit code for err. Errors not wrapped with New are
+// classified by their cause where possible, falling back to Failure.
+func Code(err error) int {
+	var exitErr *Error
+	switch {
+	case err == nil:
+		return OK
+	case errors.As(err, &exitErr):
+		return exitErr.Code
+	case errors.Is(err, exec.ErrNotFound):
+		return ToolMissing
+	case errors.Is(err, os.ErrNotExist):
+		return NotFound
+	}
+	return Failure
+}
+
+// Kind returns a stable name for an exit code, for machine-readable output
+func Kind(code int) string {
+	if kind, ok := kinds[code]; ok {
+		return kind
+	}
+	return kinds[Failure]
+}
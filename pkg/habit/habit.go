@@ -0,0 +1,212 @@
+package habit
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hezhizhen/sak/pkg/config"
+	"github.com/hezhizhen/sak/pkg/dryrun"
+	"github.com/hezhizhen/sak/pkg/exit"
+	"github.com/hezhizhen/sak/pkg/i18n"
+)
+
+// DateLayout is the format of the dates in the habits file
+const DateLayout = "2006-01-02"
+
+// mark is the cell value of a checked day
+const mark = "x"
+
+// Tracker holds the tracked habits and the days each one was checked. It is
+// stored as a CSV file with a date column followed by one column per habit,
+// and a row for every day on which something was checked.
+type Tracker struct {
+	// Habits are the habit names in the order they were added
+	Habits []string
+	// checks maps habit names to the checked dates
+	checks map[string]map[string]bool
+}
+
+// Stats summarizes the checks of a habit
+type Stats struct {
+	// Current is the number of consecutive days checked up to today, or up
+	// to yesterday while today isn't checked yet
+	Current int `json:"current" yaml:"current"`
+	// Best is the longest run of consecutive checked days
+	Best int `json:"best" yaml:"best"`
+	// Total is the number of checked days
+	Total int `json:"total" yaml:"total"`
+}
+
+// Path returns the location of the habits file, habits.csv in the data
+// directory
+func Path() (string, error) {
+	dir, err := config.DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "habits.csv"), nil
+}
+
+// Load reads the habits file, returning an empty tracker if it doesn't
+// exist yet
+func Load() (*Tracker, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	t := &Tracker{checks: map[string]map[string]bool{}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return t, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		return nil, exit.New(exit.ParseError, i18n.Errorf("parse %s: %w", path, err))
+	}
+	if len(records) == 0 {
+		return t, nil
+	}
+	t.Habits = append(t.Habits, records[0][1:]...)
+	for _, name := range t.Habits {
+		t.checks[name] = map[string]bool{}
+	}
+	for _, record := range records[1:] {
+		if _, err := time.Parse(DateLayout, record[0]); err != nil {
+			return nil, exit.New(exit.ParseError, i18n.Errorf("parse %s: invalid date %q", path, record[0]))
+		}
+		for i, cell := range record[1:] {
+			if strings.TrimSpace(cell) != "" {
+				t.checks[t.Habits[i]][record[0]] = true
+			}
+		}
+	}
+	return t, nil
+}
+
+// Save writes the tracker to the habits file
+func (t *Tracker) Save(ctx context.Context) error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+
+	dates := map[string]bool{}
+	for _, checked := range t.checks {
+		for date := range checked {
+			dates[date] = true
+		}
+	}
+	sorted := make([]string, 0, len(dates))
+	for date := range dates {
+		sorted = append(sorted, date)
+	}
+	sort.Strings(sorted)
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write(append([]string{"date"}, t.Habits...))
+	for _, date := range sorted {
+		record := []string{date}
+		for _, name := range t.Habits {
+			cell := ""
+			if t.checks[name][date] {
+				cell = mark
+			}
+			record = append(record, cell)
+		}
+		w.Write(record)
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return err
+	}
+
+	if err := dryrun.MkdirAll(ctx, filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return dryrun.WriteFile(ctx, path, buf.Bytes(), 0o644)
+}
+
+// Add starts tracking a new habit
+func (t *Tracker) Add(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" || strings.EqualFold(name, "date") {
+		return exit.New(exit.Usage, i18n.Errorf("invalid habit name %q", name))
+	}
+	if t.Has(name) {
+		return exit.New(exit.Usage, i18n.Errorf("habit %q already exists", name))
+	}
+	t.Habits = append(t.Habits, name)
+	t.checks[name] = map[string]bool{}
+	return nil
+}
+
+// Has reports whether name is a tracked habit
+func (t *Tracker) Has(name string) bool {
+	_, ok := t.checks[name]
+	return ok
+}
+
+// Check marks the habit as done, or not done, on day
+func (t *Tracker) Check(name string, day time.Time, done bool) error {
+	if !t.Has(name) {
+		return exit.New(exit.NotFound, i18n.Errorf("unknown habit %q", name))
+	}
+	if done {
+		t.checks[name][day.Format(DateLayout)] = true
+	} else {
+		delete(t.checks[name], day.Format(DateLayout))
+	}
+	return nil
+}
+
+// Done reports whether the habit was checked on day
+func (t *Tracker) Done(name string, day time.Time) bool {
+	return t.checks[name][day.Format(DateLayout)]
+}
+
+// Stats computes the streaks of the habit as of today
+func (t *Tracker) Stats(name string, today time.Time) Stats {
+	checked := t.checks[name]
+	stats := Stats{Total: len(checked)}
+
+	day := today
+	if !checked[day.Format(DateLayout)] {
+		day = day.AddDate(0, 0, -1)
+	}
+	for checked[day.Format(DateLayout)] {
+		stats.Current++
+		day = day.AddDate(0, 0, -1)
+	}
+
+	dates := make([]time.Time, 0, len(checked))
+	for date := range checked {
+		if d, err := time.Parse(DateLayout, date); err == nil {
+			dates = append(dates, d)
+		}
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+	run := 0
+	for i, d := range dates {
+		if i > 0 && d.Equal(dates[i-1].AddDate(0, 0, 1)) {
+			run++
+		} else {
+			run = 1
+		}
+		if run > stats.Best {
+			stats.Best = run
+		}
+	}
+	return stats
+}
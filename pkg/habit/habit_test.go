@@ -0,0 +1,209 @@
+package habit
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hezhizhen/sak/pkg/exit"
+)
+
+func TestStats(t *testing.T) {
+	today := time.Date(2025, 7, 16, 21, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		checked []string
+		want    Stats
+	}{
+		{"never checked", nil, Stats{}},
+		{"only today", []string{"2025-07-16"}, Stats{Current: 1, Best: 1, Total: 1}},
+		{
+			name:    "run up to today",
+			checked: []string{"2025-07-14", "2025-07-15", "2025-07-16"},
+			want:    Stats{Current: 3, Best: 3, Total: 3},
+		},
+		{
+			name:    "today not checked yet",
+			checked: []string{"2025-07-14", "2025-07-15"},
+			want:    Stats{Current: 2, Best: 2, Total: 2},
+		},
+		{
+			name:    "broken by a missed day",
+			checked: []string{"2025-07-13", "2025-07-14"},
+			want:    Stats{Current: 0, Best: 2, Total: 2},
+		},
+		{
+			name:    "a gap in the run",
+			checked: []string{"2025-07-12", "2025-07-14", "2025-07-15", "2025-07-16"},
+			want:    Stats{Current: 3, Best: 3, Total: 4},
+		},
+		{
+			name:    "best run in the past",
+			checked: []string{"2025-07-01", "2025-07-02", "2025-07-03", "2025-07-04", "2025-07-16"},
+			want:    Stats{Current: 1, Best: 4, Total: 5},
+		},
+		{
+			name:    "run across months and years",
+			checked: []string{"2024-12-30", "2024-12-31", "2025-01-01", "2025-02-28", "2025-03-01"},
+			want:    Stats{Current: 0, Best: 3, Total: 5},
+		},
+		{
+			name:    "run across a leap day",
+			checked: []string{"2024-02-28", "2024-02-29", "2024-03-01"},
+			want:    Stats{Current: 0, Best: 3, Total: 3},
+		},
+		{
+			name:    "checks after today",
+			checked: []string{"2025-07-16", "2025-07-17", "2025-07-18"},
+			want:    Stats{Current: 1, Best: 3, Total: 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := newTracker(t, "run", tt.checked...)
+			if got := tracker.Stats("run", today); got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatsAcrossDaylightSaving(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("no time zone database:", err)
+	}
+	// clocks went forward on 2025-03-09 and back on 2025-11-02
+	tests := []struct {
+		today   time.Time
+		checked []string
+	}{
+		{time.Date(2025, 3, 10, 0, 30, 0, 0, loc), []string{"2025-03-08", "2025-03-09", "2025-03-10"}},
+		{time.Date(2025, 11, 3, 23, 30, 0, 0, loc), []string{"2025-11-01", "2025-11-02", "2025-11-03"}},
+	}
+	for _, tt := range tests {
+		tracker := newTracker(t, "run", tt.checked...)
+		want := Stats{Current: 3, Best: 3, Total: 3}
+		if got := tracker.Stats("run", tt.today); got != want {
+			t.Errorf("Stats as of %v = %+v, want %+v", tt.today, got, want)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tracker := newTracker(t, "run")
+	day := time.Date(2025, 7, 16, 0, 0, 0, 0, time.UTC)
+	if err := tracker.Check("run", day, true); err != nil {
+		t.Fatal(err)
+	}
+	if !tracker.Done("run", day) {
+		t.Error("checked day is not done")
+	}
+	if err := tracker.Check("run", day, false); err != nil {
+		t.Fatal(err)
+	}
+	if tracker.Done("run", day) {
+		t.Error("unchecked day is still done")
+	}
+	if err := tracker.Check("swim", day, true); exit.Code(err) != exit.NotFound {
+		t.Errorf("checking an unknown habit: got %v, want a not found error", err)
+	}
+}
+
+func TestAdd(t *testing.T) {
+	tracker := newTracker(t, "run")
+	for _, name := range []string{"", "  ", "date", "Date", "run", " run "} {
+		if err := tracker.Add(name); exit.Code(err) != exit.Usage {
+			t.Errorf("Add(%q): got %v, want a usage error", name, err)
+		}
+	}
+	if err := tracker.Add(" swim "); err != nil {
+		t.Fatal(err)
+	}
+	if !tracker.Has("swim") {
+		t.Error("added habit is not tracked")
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	tracker := newTracker(t, "run", "2025-07-15", "2025-07-16")
+	if err := tracker.Add("read, daily"); err != nil {
+		t.Fatal(err)
+	}
+	if err := tracker.Check("read, daily", time.Date(2025, 7, 14, 0, 0, 0, 0, time.UTC), true); err != nil {
+		t.Fatal(err)
+	}
+	if err := tracker.Save(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	path, err := Path()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "date,run,\"read, daily\"\n2025-07-14,,x\n2025-07-15,x,\n2025-07-16,x,\n"
+	if string(data) != want {
+		t.Errorf("got file\n%s\nwant\n%s", data, want)
+	}
+
+	loaded, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	today := time.Date(2025, 7, 16, 0, 0, 0, 0, time.UTC)
+	if got, want := loaded.Stats("run", today), (Stats{Current: 2, Best: 2, Total: 2}); got != want {
+		t.Errorf("run: got %+v, want %+v", got, want)
+	}
+	if got, want := loaded.Stats("read, daily", today), (Stats{Current: 0, Best: 1, Total: 1}); got != want {
+		t.Errorf("read, daily: got %+v, want %+v", got, want)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	for name, content := range map[string]string{
+		"invalid date":   "date,run\n2025-13-01,x\n",
+		"ragged row":     "date,run\n2025-07-16,x,x\n",
+		"unclosed quote": "date,\"run\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			t.Setenv("XDG_DATA_HOME", dir)
+			if err := os.MkdirAll(filepath.Join(dir, "sak"), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(dir, "sak", "habits.csv"), []byte(content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := Load()
+			if code := exit.Code(err); code != exit.ParseError {
+				t.Errorf("got exit code %d (%v), want %d", code, err, exit.ParseError)
+			}
+		})
+	}
+}
+
+// newTracker returns a tracker of the habit checked on the given dates
+func newTracker(t *testing.T, name string, dates ...string) *Tracker {
+	t.Helper()
+	tracker := &Tracker{checks: map[string]map[string]bool{}}
+	if err := tracker.Add(name); err != nil {
+		t.Fatal(err)
+	}
+	for _, date := range dates {
+		day, err := time.Parse(DateLayout, date)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := tracker.Check(name, day, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return tracker
+}
@@ -0,0 +1,139 @@
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hezhizhen/sak/pkg/config"
+	"github.com/hezhizhen/sak/pkg/utils"
+)
+
+// MaxEntries is the number of entries kept when the history file is trimmed
+const MaxEntries = 1000
+
+// maxSize is the size above which the history file is trimmed
+const maxSize = 1 << 20
+
+// Entry is a recorded sak invocation
+type Entry struct {
+	// ID identifies the entry, and is kept when the file is trimmed
+	ID       int       `json:"id" yaml:"id"`
+	Time     time.Time `json:"time" yaml:"time"`
+	Args     []string  `json:"args" yaml:"args"`
+	ExitCode int       `json:"exitCode" yaml:"exitCode"`
+	// Redacted is set when secrets were removed from Args
+	Redacted bool `json:"redacted,omitempty" yaml:"redacted,omitempty"`
+}
+
+// Path returns the location of the history file, sak/history.jsonl under
+// $XDG_STATE_HOME or ~/.local/state
+func Path() (string, error) {
+	dir, err := config.StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "history.jsonl"), nil
+}
+
+// Append records an entry at the end of the history file, giving it the ID
+// following the last one
+func Append(e Entry) error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	entries, err := load(path)
+	if err != nil {
+		return err
+	}
+	e.ID = 1
+	if len(entries) > 0 {
+		e.ID = entries[len(entries)-1].ID + 1
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	if info, err := os.Stat(path); err == nil && info.Size() > maxSize {
+		return trim(path)
+	}
+	return nil
+}
+
+// Load returns all recorded entries, oldest first
+func Load() ([]Entry, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	return load(path)
+}
+
+func load(path string) ([]Entry, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxSize)
+	for scanner.Scan() {
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		// entries recorded before IDs were stored are numbered by position
+		if e.ID == 0 {
+			e.ID = 1
+			if len(entries) > 0 {
+				e.ID = entries[len(entries)-1].ID + 1
+			}
+		}
+		entries = append(entries, e)
+	}
+	return entries, scanner.Err()
+}
+
+// trim rewrites the history file keeping only the last MaxEntries entries
+func trim(path string) error {
+	entries, err := load(path)
+	if err != nil {
+		return err
+	}
+	if len(entries) > MaxEntries {
+		entries = entries[len(entries)-MaxEntries:]
+	}
+	var data []byte
+	for _, e := range entries {
+		line, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		data = append(append(data, line...), '\n')
+	}
+	return utils.WriteFileAtomic(path, data, 0o600)
+}
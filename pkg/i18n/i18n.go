@@ -0,0 +1,63 @@
+package i18n
+
+import (
+	"fmt"
+)
+
+// Language is a language sak can render its output in
+type Language string
+
+const (
+	// English is the language the messages are written in
+	English Language = "en"
+	// Chinese is simplified Chinese
+	Chinese Language = "zh"
+)
+
+// catalogs maps every language but English to its translations, keyed by
+// the English message
+var catalogs = map[Language]map[string]string{
+	Chinese: zh,
+}
+
+var current = English
+
+// ParseLanguage validates a language code, the empty string meaning English
+func ParseLanguage(s string) (Language, error) {
+	switch Language(s) {
+	case "", English:
+		return English, nil
+	case Chinese:
+		return Chinese, nil
+	}
+	return "", Errorf("unknown language %q (expected en or zh)", s)
+}
+
+// SetLanguage selects the language messages are translated to
+func SetLanguage(l Language) {
+	current = l
+}
+
+// Current returns the selected language
+func Current() Language {
+	return current
+}
+
+// T translates msg to the selected language, returning msg unchanged if
+// there is no translation
+func T(msg string) string {
+	if translated, ok := catalogs[current][msg]; ok {
+		return translated
+	}
+	return msg
+}
+
+// Sprintf formats according to the translation of format
+func Sprintf(format string, args ...interface{}) string {
+	return fmt.Sprintf(T(format), args...)
+}
+
+// Errorf is fmt.Errorf with the translation of format
+func Errorf(format string, args ...interface{}) error {
+	return fmt.Errorf(T(format), args...)
+}
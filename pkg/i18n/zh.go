@@ -0,0 +1,1283 @@
+package i18n
+
+var zh = map[string]string{
+	// cobra usage template
+	"Usage:":                  "用法：",
+	"Aliases:":                "别名：",
+	"Examples:":               "示例：",
+	"Available Commands:":     "可用命令：",
+	"Additional Commands:":    "其他命令：",
+	"Global Flags:":           "全局参数：",
+	"Flags:":                  "参数：",
+	"Additional help topics:": "其他帮助主题：",
+	`Use "{{.CommandPath}} [command] --help" for more information about a command.`: `使用 "{{.CommandPath}} [command] --help" 查看命令的详细信息。`,
+
+	// root
+	"My tool set": "我的工具集",
+	`My tool set
+
+Exit codes:
+  0  success
+  1  failure
+  2  invalid usage
+  3  not found
+  4  parse error
+  5  external tool missing
+  6  aborted by the user
+`: `我的工具集
+
+退出码：
+  0  成功
+  1  失败
+  2  用法错误
+  3  未找到
+  4  解析错误
+  5  缺少外部工具
+  6  用户中止
+`,
+	"Error:": "错误：",
+	"output format: table, json, yaml or markdown":    "输出格式：table、json、yaml 或 markdown",
+	"disable colored output":                          "禁用彩色输出",
+	"only print what would be changed":                "只打印将要进行的修改",
+	"configuration profile to use":                    "要使用的配置档案",
+	"error output format: text or json":               "错误输出格式：text 或 json",
+	"answer yes to every confirmation":                "对所有确认回答是",
+	"never prompt; take the default answers":          "从不提示，采用默认回答",
+	"unknown error format %q (expected text or json)": "未知的错误输出格式 %q（应为 text 或 json）",
+
+	// version
+	"Show the sak version information": "显示 sak 版本信息",
+	`Show the sak version information
+
+Example - print version:
+  sak version
+
+Example - also check whether a newer release exists:
+  sak version --check-latest
+
+Example - print version as JSON:
+  sak version --output json
+`: `显示 sak 版本信息
+
+示例 - 打印版本：
+  sak version
+
+示例 - 同时检查是否有更新的版本：
+  sak version --check-latest
+
+示例 - 以 JSON 格式打印版本：
+  sak version --output json
+`,
+	"check GitHub for a newer release":      "在 GitHub 上检查是否有更新的版本",
+	"failed to check latest release: %v":    "检查最新版本失败：%v",
+	"Version":                               "版本",
+	"Go version":                            "Go 版本",
+	"Git commit":                            "Git 提交",
+	"Git tree state":                        "Git 树状态",
+	"Latest version":                        "最新版本",
+	"%s (run `sak self-update` to upgrade)": "%s（运行 `sak self-update` 升级）",
+	"%s (up to date)":                       "%s（已是最新）",
+
+	// self-update
+	"Update sak to the latest release": "将 sak 更新到最新版本",
+	`Update sak to the latest release
+
+The binary for the current platform is downloaded from the latest GitHub
+release, verified against the release checksums and swapped in place of
+the running executable.
+
+Example - only report whether a newer version is available:
+  sak self-update --check
+
+Example - update without asking for confirmation:
+  sak self-update --yes
+`: `将 sak 更新到最新版本
+
+从 GitHub 最新版本中下载当前平台的可执行文件，校验其 checksum 后替换正在运行的
+可执行文件。
+
+示例 - 只报告是否有更新的版本：
+  sak self-update --check
+
+示例 - 不经确认直接更新：
+  sak self-update --yes
+`,
+	"only report the available version":               "只报告可用的版本",
+	"check latest release: %w":                        "检查最新版本：%w",
+	"sak is up to date (%s)":                          "sak 已是最新版本（%s）",
+	"A new version is available: %s -> %s":            "有新版本可用：%s -> %s",
+	"release %s has no binary for this platform (%s)": "版本 %s 没有当前平台的可执行文件（%s）",
+	"release %s has no %s":                            "版本 %s 缺少 %s",
+	"Update to %s?":                                   "更新到 %s？",
+	"download checksums: %w":                          "下载 checksum：%w",
+	"download %s: %w":                                 "下载 %s：%w",
+	"replace %s with %s (%d bytes)":                   "将 %[1]s 替换为 %[2]s（%[3]d 字节）",
+	"replace %s: %w":                                  "替换 %s：%w",
+	"Updated %s to %s":                                "已将 %s 更新到 %s",
+	"update cancelled":                                "已取消更新",
+	"decode release: %w":                              "解析版本信息：%w",
+	"checksum mismatch for %s: expected %s, got %s":   "%s 的 checksum 不匹配：应为 %s，实际为 %s",
+	"no checksum found for %s":                        "未找到 %s 的 checksum",
+	"GET %s: %s":                                      "请求 %s：%s",
+	"A new release of sak is available: %s -> %s, released %s (run `sak self-update`)": "sak 有新版本可用：%s -> %s，发布于%s（运行 `sak self-update`）",
+
+	// docs
+	"Generate documentation for sak":           "生成 sak 文档",
+	"Generate man pages for every sak command": "为每个 sak 命令生成 man 手册",
+	`Generate man pages for every sak command
+
+One page is written per command, named after its full path, e.g.
+sak-version.1 for "sak version".
+
+Example - generate man pages into ./man:
+  sak docs man -o ./man
+`: `为每个 sak 命令生成 man 手册
+
+每个命令生成一页，以命令的完整路径命名，例如 "sak version" 对应 sak-version.1。
+
+示例 - 在 ./man 中生成 man 手册：
+  sak docs man -o ./man
+`,
+	"directory to write the man pages to": "man 手册的输出目录",
+	"Man pages written to %s":             "man 手册已写入 %s",
+
+	// config
+	"Read and modify the sak configuration": "读取和修改 sak 配置",
+	`Read and modify the sak configuration
+
+The configuration file is read from $SAK_CONFIG, or sak/config.yaml under
+$XDG_CONFIG_HOME (default ~/.config).
+
+Example - list all known keys and their values:
+  sak config list
+
+Example - set the editor used by sak:
+  sak config set editor "code --wait"
+
+Example - set the editor of the work profile only:
+  sak --profile work config set editor "code --wait"
+`: `读取和修改 sak 配置
+
+配置文件为 $SAK_CONFIG，或 $XDG_CONFIG_HOME（默认 ~/.config）下的
+sak/config.yaml。
+
+示例 - 列出所有配置项及其值：
+  sak config list
+
+示例 - 设置 sak 使用的编辑器：
+  sak config set editor "code --wait"
+
+示例 - 只设置 work 档案的编辑器：
+  sak --profile work config set editor "code --wait"
+`,
+	"Print the value of a configuration key":       "打印配置项的值",
+	"Set the value of a configuration key":         "设置配置项的值",
+	"List all configuration keys and their values": "列出所有配置项及其值",
+	"Open the configuration file in an editor":     "在编辑器中打开配置文件",
+	"invalid value for %s: %w":                     "%s 的值无效：%w",
+	"run editor: %w":                               "运行编辑器：%w",
+	"config is invalid after editing: %w":          "编辑后的配置无效：%w",
+	"unknown config key %q":                        "未知的配置项 %q",
+	"expected true or false":                       "应为 true 或 false",
+	"parse %s: %w":                                 "解析 %s：%w",
+
+	// profile
+	"Manage configuration profiles": "管理配置档案",
+	`Manage configuration profiles
+
+A profile is a named set of settings under the profiles key of the config
+file, overriding the top-level settings while it is active. The active
+profile is the one given with the global --profile flag, or else the one
+selected with "sak profile use".
+
+Example - create a work profile with its own editor:
+  sak --profile work config set editor "code --wait"
+
+Example - make the work profile the default:
+  sak profile use work
+`: `管理配置档案
+
+档案是配置文件 profiles 下一组命名的配置，启用时覆盖顶层配置。启用的档案由
+全局参数 --profile 指定，否则为 "sak profile use" 选择的档案。
+
+示例 - 创建使用单独编辑器的 work 档案：
+  sak --profile work config set editor "code --wait"
+
+示例 - 将 work 档案设为默认：
+  sak profile use work
+`,
+	"List the configured profiles":       "列出已配置的档案",
+	"Select the profile used by default": "选择默认使用的档案",
+	"No profiles configured":             "没有配置任何档案",
+	"unknown profile %q":                 "未知的档案 %q",
+	"unknown profile %q, create it with `sak --profile %s config set <key> <value>`": "未知的档案 %q，可以用 `sak --profile %s config set <key> <value>` 创建",
+
+	// plugin
+	"Manage external sak plugins": "管理外部 sak 插件",
+	`Manage external sak plugins
+
+Any executable named sak-<name> on $PATH can be run as "sak <name>".
+Arguments are passed through unchanged. Global flags given before the name
+are not: SAK_CONFIG and SAK_OUTPUT (the value of --output) are set in its
+environment, and SAK_PROFILE as well when --profile is given.
+
+Example - list the plugins found on $PATH:
+  sak plugin list
+`: `管理外部 sak 插件
+
+$PATH 中任何名为 sak-<name> 的可执行文件都可以通过 "sak <name>" 运行。参数原样
+传递，但名称之前的全局参数不会传递：其环境变量中会设置 SAK_CONFIG 和
+SAK_OUTPUT（--output 的值），指定 --profile 时还会设置 SAK_PROFILE。
+
+示例 - 列出 $PATH 中的插件：
+  sak plugin list
+`,
+	"List the plugins found on $PATH": "列出 $PATH 中的插件",
+	"No plugins found on $PATH":       "$PATH 中没有找到插件",
+	"NAME":                            "名称",
+	"PATH":                            "路径",
+	"ACTIVE":                          "当前",
+	"run plugin %s: %v":               "运行插件 %s：%v",
+
+	// recent
+	"List or re-run recent sak invocations": "列出或重新运行最近的 sak 调用",
+	`List or re-run recent sak invocations
+
+Every sak invocation is recorded in sak/history.jsonl under $XDG_STATE_HOME
+(default ~/.local/state) unless the history config key is set to false.
+
+Example - list the last 20 invocations:
+  sak recent
+
+Example - list what ran last week:
+  sak recent --range last-week -n 0
+
+Example - re-run invocation 42:
+  sak recent --rerun 42
+`: `列出或重新运行最近的 sak 调用
+
+每次 sak 调用都会记录在 $XDG_STATE_HOME（默认 ~/.local/state）下的
+sak/history.jsonl 中，除非配置项 history 设为 false。
+
+示例 - 列出最近 20 次调用：
+  sak recent
+
+示例 - 列出上周的调用：
+  sak recent --range last-week -n 0
+
+示例 - 重新运行第 42 次调用：
+  sak recent --rerun 42
+`,
+	"number of invocations to list":                                                    "列出的调用次数",
+	"re-run the invocation with this ID":                                               "重新运行此 ID 的调用",
+	"only list invocations in a date range, e.g. today, last-week or 2025-01..2025-03": "只列出日期范围内的调用，例如 today、last-week 或 2025-01..2025-03",
+	"ID":                       "ID",
+	"TIME":                     "时间",
+	"EXIT":                     "退出码",
+	"COMMAND":                  "命令",
+	"no invocation with ID %d": "没有 ID 为 %d 的调用",
+	"invocation %d had secrets removed from its arguments and cannot be re-run": "调用 %d 的参数中的机密已被移除，无法重新运行",
+
+	// shell
+	"Run sak commands in an interactive prompt": "在交互式提示符中运行 sak 命令",
+	`Run sak commands in an interactive prompt
+
+Each line is run as a sak command line without the leading "sak". Commands
+and flags are completed with Tab, and the prompt history is kept across
+sessions. Type "exit" or press Ctrl-D to leave.
+
+Example - start the prompt:
+  sak shell
+`: `在交互式提示符中运行 sak 命令
+
+每一行作为省略开头 "sak" 的 sak 命令运行。按 Tab 补全命令和参数，提示符历史
+在会话之间保留。输入 "exit" 或按 Ctrl-D 退出。
+
+示例 - 启动提示符：
+  sak shell
+`,
+	"already in a sak shell": "已经在 sak shell 中",
+	"unterminated %c quote":  "%c 引号未闭合",
+	"trailing backslash":     "末尾有多余的反斜杠",
+
+	// dry-run
+	"[dry-run] would":         "[试运行] 将会",
+	"overwrite %s (%d bytes)": "覆盖 %s（%d 字节）",
+	"create %s (%d bytes)":    "创建 %s（%d 字节）",
+	"append to %s: %s":        "追加到 %s：%s",
+	"create directory %s":     "创建目录 %s",
+	"run %s":                  "运行 %s",
+
+	// relative time
+	"just now":       "刚刚",
+	"%d minute ago":  "%d 分钟前",
+	"%d minutes ago": "%d 分钟前",
+	"%d hour ago":    "%d 小时前",
+	"%d hours ago":   "%d 小时前",
+	"%d day ago":     "%d 天前",
+	"%d days ago":    "%d 天前",
+	"%d week ago":    "%d 周前",
+	"%d weeks ago":   "%d 周前",
+	"%d month ago":   "%d 个月前",
+	"%d months ago":  "%d 个月前",
+	"%d year ago":    "%d 年前",
+	"%d years ago":   "%d 年前",
+	"in %d minute":   "%d 分钟后",
+	"in %d minutes":  "%d 分钟后",
+	"in %d hour":     "%d 小时后",
+	"in %d hours":    "%d 小时后",
+	"in %d day":      "%d 天后",
+	"in %d days":     "%d 天后",
+	"in %d week":     "%d 周后",
+	"in %d weeks":    "%d 周后",
+	"in %d month":    "%d 个月后",
+	"in %d months":   "%d 个月后",
+	"in %d year":     "%d 年后",
+	"in %d years":    "%d 年后",
+
+	// settings
+	"unknown language %q (expected en or zh)":                                                          "未知的语言 %q（应为 en 或 zh）",
+	"unknown color mode %q (expected auto, always or never)":                                           "未知的颜色模式 %q（应为 auto、always 或 never）",
+	"unknown output format %q (expected table, json, yaml or markdown)":                                "未知的输出格式 %q（应为 table、json、yaml 或 markdown）",
+	"table output is not supported for %T":                                                             "%T 不支持表格输出",
+	"unknown color %q (expected a name such as green, bright-red or bold, or ANSI codes such as 1;35)": "未知的颜色 %q（应为 green、bright-red、bold 等名称，或 1;35 等 ANSI 代码）",
+
+	// pkg/utils
+	"invalid duration %q (expected e.g. 9h30m, 9:30 or 9.5h)":                                           "无效的时长 %q（示例：9h30m、9:30 或 9.5h）",
+	"duration %q has no unit (did you mean %sh or %sm?)":                                                "时长 %q 缺少单位（是否指 %sh 或 %sm？）",
+	"minutes and seconds must be below 60 in %q":                                                        "%q 中的分钟和秒必须小于 60",
+	"invalid time of day %q (expected e.g. 19:05 or 7pm)":                                               "无效的时刻 %q（示例：19:05 或 7pm）",
+	"hour must be between 1 and 12 in %q":                                                               "%q 中的小时必须在 1 到 12 之间",
+	"hour must be between 0 and 23 in %q":                                                               "%q 中的小时必须在 0 到 23 之间",
+	"date range %q ends before it starts":                                                               "日期范围 %q 的结束早于开始",
+	"invalid date range %q (expected e.g. today, last-week, 2025-06, 2025-01..2025-03 or past-30-days)": "无效的日期范围 %q（示例：today、last-week、2025-06、2025-01..2025-03 或 past-30-days）",
+	"Please answer yes or no.":                                                                          "请回答 yes 或 no。",
+	"Choose 1-%d [%d]: ":                                                                                "选择 1-%d [%d]：",
+	"Please enter a number between 1 and %d.":                                                           "请输入 1 到 %d 之间的数字。",
+
+	// pomodoro
+	"Run pomodoro work and break timers": "运行番茄钟工作与休息计时器",
+	`Run pomodoro work and break timers
+
+Work sessions and breaks alternate, with a longer break after every few
+sessions. The time left is shown on a live line in the terminal and a
+desktop notification is sent at every transition (notify-send on Linux,
+osascript on macOS). Press Ctrl-C to stop.
+
+Example - four classic pomodoros:
+  sak pomodoro
+
+Example - longer sessions until stopped:
+  sak pomodoro --work 50m --break 10m --rounds 0
+`: `运行番茄钟工作与休息计时器
+
+工作时段与休息交替进行，每隔几个时段进行一次长休息。剩余时间显示在终端中
+实时刷新的一行上，每次切换时都会发送桌面通知（Linux 上使用 notify-send，
+macOS 上使用 osascript）。按 Ctrl-C 停止。
+
+示例 - 四个经典番茄钟：
+  sak pomodoro
+
+示例 - 更长的时段，直到手动停止：
+  sak pomodoro --work 50m --break 10m --rounds 0
+`,
+	"durations and rounds must not be negative, and --work must be positive": "时长和轮数不能为负数，且 --work 必须为正数",
+	"length of a work session": "工作时段的长度",
+	"length of a short break":  "短休息的长度",
+	"length of the long break": "长休息的长度",
+	"take the long break after this many work sessions (0 for never)": "每完成这么多个工作时段后进行长休息（0 表示从不）",
+	"number of work sessions, 0 to run until stopped":                 "工作时段数，0 表示一直运行到停止",
+	"send desktop notifications":                                      "发送桌面通知",
+	"Work %d":                                                         "工作 %d",
+	"Work %d/%d":                                                      "工作 %d/%d",
+	"Pomodoro finished":                                               "番茄钟已完成",
+	"%d sessions done":                                                "已完成 %d 个时段",
+	"Break":                                                           "休息",
+	"Long break":                                                      "长休息",
+	"Time for a break":                                                "该休息了",
+	"Back to work":                                                    "回到工作",
+	"Work %d starts now":                                              "工作 %d 现在开始",
+	"pomodoro stopped":                                                "番茄钟已停止",
+	"Completed %d work sessions (%s)":                                 "已完成 %d 个工作时段（%s）",
+	"warning: desktop notifications disabled: %v":                     "警告：桌面通知已停用：%v",
+
+	// desktop notifications
+	"desktop notifications are not supported on %s": "%s 上不支持桌面通知",
+	"send notification: %w":                         "发送通知：%w",
+
+	// timer
+	"Count down and notify when time is up": "倒计时并在时间到时通知",
+	`Count down and notify when time is up
+
+The duration is written like 10m, 1h30m, 1:30 or 2.5h. With --at the timer
+runs until a time of day instead, and the only argument is the label. A
+desktop notification is sent when time is up. Press Ctrl-C to cancel.
+
+Example - a tea timer:
+  sak timer 4m tea
+
+Example - remind me at 7pm:
+  sak timer --at 7pm "call home"
+`: `倒计时并在时间到时通知
+
+时长的写法如 10m、1h30m、1:30 或 2.5h。使用 --at 时计时器会运行到某个时刻，
+唯一的参数是标签。时间到时会发送桌面通知。按 Ctrl-C 取消。
+
+示例 - 泡茶计时：
+  sak timer 4m tea
+
+示例 - 晚上 7 点提醒我：
+  sak timer --at 7pm "call home"
+`,
+	"Timer":                         "计时器",
+	"the duration must be positive": "时长必须为正数",
+	"expected a duration, or --at with an optional label": "应为一个时长，或 --at 加可选的标签",
+	"count down to a time of day, e.g. 19:05 or 7pm":      "倒计时到某个时刻，例如 19:05 或 7pm",
+	"timer cancelled": "计时器已取消",
+	"Time is up":      "时间到",
+
+	// stopwatch
+	"Measure elapsed time with laps": "带分圈的秒表",
+	`Measure elapsed time with laps
+
+Press Enter to complete a lap, and q then Enter or Ctrl-C to stop. The
+laps are listed when the stopwatch stops.
+
+Example - time a few rounds:
+  sak stopwatch
+
+Example - get the laps as JSON:
+  sak stopwatch --output json
+`: `带分圈的秒表
+
+按 Enter 完成一圈，输入 q 后按 Enter 或按 Ctrl-C 停止。秒表停止时会列出
+各圈时间。
+
+示例 - 为几轮计时：
+  sak stopwatch
+
+示例 - 以 JSON 获取各圈时间：
+  sak stopwatch --output json
+`,
+	"Stopwatch": "秒表",
+	"lap %d":    "第 %d 圈",
+	"Lap %d":    "第 %d 圈",
+	"LAP":       "圈",
+	"TOTAL":     "累计",
+
+	// habit
+	"Track daily habits": "追踪每日习惯",
+	`Track daily habits
+
+Habits are checked off once a day and stored in sak/habits.csv under
+$XDG_DATA_HOME (default ~/.local/share), with a column per habit and a row
+per day.
+
+Example - start tracking a habit:
+  sak habit add read
+
+Example - check it off for today:
+  sak habit check read
+
+Example - show streaks and a heatmap of the last 12 weeks:
+  sak habit stats read
+`: `追踪每日习惯
+
+习惯每天打卡一次，保存在 $XDG_DATA_HOME（默认 ~/.local/share）下的
+sak/habits.csv 中，每个习惯一列，每天一行。
+
+示例 - 开始追踪一个习惯：
+  sak habit add read
+
+示例 - 为今天打卡：
+  sak habit check read
+
+示例 - 显示连续天数和最近 12 周的热力图：
+  sak habit stats read
+`,
+	"Start tracking a habit":                       "开始追踪一个习惯",
+	"Check off a habit for today or another day":   "为今天或其他日期打卡",
+	"--date must be a single day, got %q":          "--date 必须是单独的一天，得到的是 %q",
+	"day to check, e.g. yesterday or 2025-07-01":   "打卡的日期，例如 yesterday 或 2025-07-01",
+	"remove the check instead":                     "改为取消打卡",
+	"Show habit streaks and heatmaps":              "显示习惯的连续天数和热力图",
+	"number of weeks in the heatmap, 0 to hide it": "热力图中的周数，0 表示隐藏",
+	"HABIT":                     "习惯",
+	"TODAY":                     "今天",
+	"STREAK":                    "连续",
+	"BEST":                      "最长",
+	"Mon":                       "周一",
+	"Tue":                       "周二",
+	"Wed":                       "周三",
+	"Thu":                       "周四",
+	"Fri":                       "周五",
+	"Sat":                       "周六",
+	"Sun":                       "周日",
+	"unknown habit %q":          "未知的习惯 %q",
+	"invalid habit name %q":     "无效的习惯名称 %q",
+	"habit %q already exists":   "习惯 %q 已存在",
+	"parse %s: invalid date %q": "解析 %s：无效的日期 %q",
+
+	// note
+	"Capture quick notes in an inbox": "在收件箱中快速记录笔记",
+	`Capture quick notes in an inbox
+
+Notes are appended as timestamped list items to sak/inbox.md under
+$XDG_DATA_HOME (default ~/.local/share), a plain Markdown file that can be
+edited by hand. Use -- before a note starting with list or grep.
+
+Example - capture an idea:
+  sak note idea about X
+
+Example - list this week's notes:
+  sak note list --range this-week
+
+Example - find notes mentioning docker:
+  sak note grep docker
+`: `在收件箱中快速记录笔记
+
+笔记以带时间戳的列表项追加到 $XDG_DATA_HOME（默认 ~/.local/share）下的
+sak/inbox.md，这是一个可以手动编辑的普通 Markdown 文件。以 list 或 grep
+开头的笔记前请加上 --。
+
+示例 - 记录一个想法：
+  sak note idea about X
+
+示例 - 列出本周的笔记：
+  sak note list --range this-week
+
+示例 - 查找提到 docker 的笔记：
+  sak note grep docker
+`,
+	"List the latest notes":                                    "列出最近的笔记",
+	"number of notes to list, 0 for all":                       "列出的笔记数，0 表示全部",
+	"only list notes in a date range, e.g. today or last-week": "只列出日期范围内的笔记，例如 today 或 last-week",
+	"Search notes with a regular expression":                   "用正则表达式搜索笔记",
+	`Search notes with a regular expression
+
+The search ignores case unless the pattern contains an upper-case letter.
+
+Example - find notes about the release:
+  sak note grep 'release|deploy'
+`: `用正则表达式搜索笔记
+
+除非模式中包含大写字母，否则搜索忽略大小写。
+
+示例 - 查找关于发布的笔记：
+  sak note grep 'release|deploy'
+`,
+	"invalid pattern: %w": "无效的模式：%w",
+	"no notes match %q":   "没有匹配 %q 的笔记",
+	"always ignore case":  "始终忽略大小写",
+	"NOTE":                "笔记",
+
+	// bookmarks
+	"Manage bookmarks": "管理书签",
+	`Manage bookmarks
+
+Bookmarks are stored in bookmarks.json next to the configuration file, so
+they can be synced along with it. The page title is fetched when a
+bookmark is added without --title.
+
+Example - bookmark a page with tags:
+  sak bm add https://go.dev/doc/effective_go --tags go,docs
+
+Example - find bookmarks about cobra:
+  sak bm search cobra
+
+Example - open bookmark 3 in the browser:
+  sak bm open 3
+`: `管理书签
+
+书签保存在配置文件旁的 bookmarks.json 中，可以随配置一起同步。添加书签时
+如果没有指定 --title，会自动获取页面标题。
+
+示例 - 添加带标签的书签：
+  sak bm add https://go.dev/doc/effective_go --tags go,docs
+
+示例 - 查找关于 cobra 的书签：
+  sak bm search cobra
+
+示例 - 在浏览器中打开书签 3：
+  sak bm open 3
+`,
+	"Add a bookmark":                                  "添加书签",
+	"%s is already bookmarked as %d":                  "%s 已保存为书签 %d",
+	"warning: could not fetch the title: %v":          "警告：无法获取标题：%v",
+	"title of the bookmark instead of the page title": "书签标题，代替页面标题",
+	"comma-separated tags":                            "逗号分隔的标签",
+	"List bookmarks":                                  "列出书签",
+	"only list bookmarks with this tag":               "只列出带有此标签的书签",
+	"Search bookmarks by title, URL and tags":         "按标题、URL 和标签搜索书签",
+	"no bookmarks match %q":                           "没有匹配 %q 的书签",
+	"Open a bookmark in the browser":                  "在浏览器中打开书签",
+	"Remove a bookmark":                               "删除书签",
+	"invalid bookmark ID %q":                          "无效的书签 ID %q",
+	"no bookmark with ID %d":                          "没有 ID 为 %d 的书签",
+	"invalid URL %q":                                  "无效的 URL %q",
+	"%s has no title":                                 "%s 没有标题",
+	"TITLE":                                           "标题",
+	"URL":                                             "URL",
+	"TAGS":                                            "标签",
+
+	// snippets
+	"Store and copy snippets": "保存和复制代码片段",
+	`Store and copy snippets
+
+Snippets are stored one file each in sak/snippets under $XDG_DATA_HOME
+(default ~/.local/share), so the directory can be kept in git. A file may
+start with a YAML front matter holding the description and tags.
+
+Example - save a one-liner:
+  sak snip add git-undo --tags git 'git reset --soft HEAD~1'
+
+Example - save a longer snippet from a file:
+  sak snip add nginx-proxy --tags nginx < proxy.conf
+
+Example - find a snippet and copy it to the clipboard:
+  sak snip search gitundo
+  sak snip copy git-undo
+`: `保存和复制代码片段
+
+每个片段单独保存为 $XDG_DATA_HOME（默认 ~/.local/share）下 sak/snippets
+中的一个文件，因此该目录可以用 git 管理。文件开头可以有一段 YAML front
+matter，保存描述和标签。
+
+示例 - 保存一行命令：
+  sak snip add git-undo --tags git 'git reset --soft HEAD~1'
+
+示例 - 从文件保存较长的片段：
+  sak snip add nginx-proxy --tags nginx < proxy.conf
+
+示例 - 查找片段并复制到剪贴板：
+  sak snip search gitundo
+  sak snip copy git-undo
+`,
+	"Add a snippet from the arguments or stdin":    "从参数或标准输入添加片段",
+	"no content: pass it as arguments or on stdin": "没有内容：请通过参数或标准输入提供",
+	"what the snippet does":                        "片段的用途",
+	"replace an existing snippet":                  "替换已有的片段",
+	"List snippets":                                "列出片段",
+	"only list snippets with this tag":             "只列出带有此标签的片段",
+	"Print a snippet":                              "打印片段",
+	"Copy a snippet to the clipboard":              "将片段复制到剪贴板",
+	"Copied %s to the clipboard":                   "已将 %s 复制到剪贴板",
+	"Fuzzy search snippets":                        "模糊搜索片段",
+	`Fuzzy search snippets
+
+The characters of the query must appear in order in the name, tags,
+description or content of a snippet. The best matches are listed first.
+
+Example - find "git reset --soft":
+  sak snip search gitsoft
+`: `模糊搜索片段
+
+查询中的字符必须按顺序出现在片段的名称、标签、描述或内容中。最佳匹配排在
+最前面。
+
+示例 - 查找 "git reset --soft"：
+  sak snip search gitsoft
+`,
+	"no snippets match %q": "没有匹配 %q 的片段",
+	"Remove a snippet":     "删除片段",
+	"SNIPPET":              "片段",
+	"invalid snippet name %q (use letters, digits, '.', '_' and '-')": "无效的片段名称 %q（请使用字母、数字、'.'、'_' 和 '-'）",
+	"unknown snippet %q":               "未知的片段 %q",
+	"snippet %q already exists":        "片段 %q 已存在",
+	"remove %s":                        "删除 %s",
+	"warning: skipping snippet %s: %v": "警告：跳过片段 %s：%v",
+
+	// clipboard
+	"no clipboard tool found (install wl-clipboard, xclip or xsel)": "未找到剪贴板工具（请安装 wl-clipboard、xclip 或 xsel）",
+	"copy to clipboard: %w":        "复制到剪贴板：%w",
+	"paste from clipboard: %w: %s": "从剪贴板粘贴：%w：%s",
+
+	// clip
+	"Copy to and paste from the system clipboard": "复制到系统剪贴板或从中粘贴",
+	`Copy to and paste from the system clipboard
+
+The clipboard is accessed with pbcopy/pbpaste on macOS, clip and
+PowerShell on Windows, and wl-clipboard, xclip or xsel elsewhere.
+
+Texts copied with sak clip copy are remembered when the clip-history config
+key is set to the number of texts to keep. The history is stored in
+sak/clipboard.jsonl under $XDG_STATE_HOME (default ~/.local/state).
+
+Example - copy the output of a command:
+  git rev-parse HEAD | sak clip copy
+
+Example - remember the last 20 copies and list them:
+  sak config set clip-history 20
+  sak clip history
+
+Example - copy history entry 3 again:
+  sak clip restore 3
+`: `复制到系统剪贴板或从中粘贴
+
+在 macOS 上使用 pbcopy/pbpaste，在 Windows 上使用 clip 和 PowerShell，
+其他系统上使用 wl-clipboard、xclip 或 xsel 访问剪贴板。
+
+将配置项 clip-history 设为要保留的条数后，会记住用 sak clip copy 复制的
+文本。历史保存在 $XDG_STATE_HOME（默认 ~/.local/state）下的
+sak/clipboard.jsonl 中。
+
+示例 - 复制命令的输出：
+  git rev-parse HEAD | sak clip copy
+
+示例 - 记住最近 20 次复制并列出：
+  sak config set clip-history 20
+  sak clip history
+
+示例 - 再次复制第 3 条历史：
+  sak clip restore 3
+`,
+	"Copy the arguments or stdin to the clipboard":  "将参数或标准输入复制到剪贴板",
+	"remove trailing line breaks":                   "去掉末尾的换行",
+	"Print the clipboard":                           "打印剪贴板内容",
+	"List the remembered copies":                    "列出记住的复制内容",
+	"forget all remembered copies":                  "清除所有记住的复制内容",
+	"Copy a remembered text to the clipboard again": "将记住的文本再次复制到剪贴板",
+	"invalid history ID %q":                         "无效的历史 ID %q",
+	"no clipboard history entry with ID %d":         "剪贴板历史中没有 ID 为 %d 的条目",
+	"TEXT":                                          "文本",
+	"expected a number of at least 0":               "应为不小于 0 的数字",
+
+	// passgen
+	"Generate a password or passphrase": "生成密码或口令短语",
+	`Generate a password or passphrase
+
+Passwords mix lowercase and uppercase letters and digits, plus symbols with
+--symbols, and contain at least one character of each. With --words a
+passphrase of random words is generated instead, as with diceware. The
+randomness comes from the operating system.
+
+The entropy of the result is printed to stderr, so only the password is
+written to stdout.
+
+Example - generate a 24 character password with symbols:
+  sak passgen --length 24 --symbols
+
+Example - copy a passphrase of six words to the clipboard:
+  sak passgen --words 6 --copy
+`: `生成密码或口令短语
+
+密码由小写字母、大写字母和数字组成，使用 --symbols 时还包含符号，
+且每类字符至少出现一次。使用 --words 时改为生成由随机单词组成的口令
+短语，类似 diceware。随机数来自操作系统。
+
+结果的熵输出到标准错误，因此标准输出中只有密码。
+
+示例 - 生成包含符号的 24 位密码：
+  sak passgen --length 24 --symbols
+
+示例 - 将六个单词的口令短语复制到剪贴板：
+  sak passgen --words 6 --copy
+`,
+	"Copied to the clipboard":                          "已复制到剪贴板",
+	"Entropy: %.0f bits (%s)":                          "熵：%.0f 位（%s）",
+	"number of characters of the password":             "密码的字符数",
+	"include symbols in the password":                  "密码中包含符号",
+	"generate a passphrase of this many words instead": "改为生成由指定数量单词组成的口令短语",
+	"separator between the words of a passphrase":      "口令短语中单词之间的分隔符",
+	"copy to the clipboard instead of printing":        "复制到剪贴板而不是打印",
+	"the length must be at least %d":                   "长度至少为 %d",
+	"the number of words must be at least 1":           "单词数至少为 1",
+	"weak":                                             "弱",
+	"fair":                                             "一般",
+	"strong":                                           "强",
+	"very strong":                                      "很强",
+
+	// id
+	"the count must be at least 1":       "数量至少为 1",
+	"Generate UUIDs, ULIDs and nano IDs": "生成 UUID、ULID 和 nano ID",
+	`Generate UUIDs, ULIDs and nano IDs
+
+UUIDs are random (version 4) unless --v7 is given, which starts them with
+the time so they sort by creation. ULIDs also sort by creation and are
+written with 26 characters of Crockford's base32. Nano IDs are short random
+strings whose alphabet is URL-safe by default.
+
+Example - generate five UUIDs in uppercase:
+  sak id uuid -n 5 --upper
+
+Example - generate a UUID for use in a URL:
+  sak id uuid --url-safe
+
+Example - generate a nano ID of 10 lowercase letters and digits:
+  sak id nanoid --size 10 --lower
+`: `生成 UUID、ULID 和 nano ID
+
+UUID 默认是随机的（版本 4）；使用 --v7 时以时间开头，因此按创建顺序排序。
+ULID 同样按创建顺序排序，由 26 个 Crockford base32 字符组成。nano ID 是
+较短的随机字符串，默认字母表可安全用于 URL。
+
+示例 - 生成五个大写的 UUID：
+  sak id uuid -n 5 --upper
+
+示例 - 生成用于 URL 的 UUID：
+  sak id uuid --url-safe
+
+示例 - 生成由 10 个小写字母和数字组成的 nano ID：
+  sak id nanoid --size 10 --lower
+`,
+	"number of IDs to generate":                             "生成的 ID 数量",
+	"print the IDs in uppercase":                            "以大写输出 ID",
+	"print the IDs in lowercase":                            "以小写输出 ID",
+	"Generate UUIDs":                                        "生成 UUID",
+	"--url-safe cannot be combined with --upper or --lower": "--url-safe 不能与 --upper 或 --lower 同时使用",
+	"generate time-ordered version 7 UUIDs":                 "生成按时间排序的版本 7 UUID",
+	"print 22 characters of URL-safe base64":                "输出 22 个字符的 URL 安全 base64",
+	"Generate ULIDs":                                        "生成 ULID",
+	"Generate nano IDs":                                     "生成 nano ID",
+	"--alphabet cannot be combined with --upper or --lower": "--alphabet 不能与 --upper 或 --lower 同时使用",
+	"number of characters of the IDs":                       "ID 的字符数",
+	"characters the IDs are made of":                        "组成 ID 的字符",
+	"the size must be at least 1":                           "长度至少为 1",
+	"the alphabet must have at least 2 ASCII characters":    "字母表至少需要 2 个 ASCII 字符",
+
+	// encode
+	"Encode and decode base64, hex and URLs": "编码和解码 base64、十六进制和 URL",
+	`Encode and decode base64, hex and URLs
+
+The arguments are joined with spaces and converted; without arguments
+stdin is read as is, including its final line break. Encoded text ends with
+a line break, decoded data is written unchanged so binary data survives.
+
+Example - encode basic auth credentials:
+  sak encode base64 'user:secret'
+
+Example - decode a base64 file:
+  sak encode base64 --decode < cert.b64 > cert.der
+
+Example - escape a query parameter:
+  sak encode url 'a&b=c d'
+`: `编码和解码 base64、十六进制和 URL
+
+参数以空格连接后转换；没有参数时按原样读取标准输入，包括末尾的换行。
+编码结果以换行结尾，解码的数据原样输出，因此二进制数据不会被破坏。
+
+示例 - 编码 basic auth 凭据：
+  sak encode base64 'user:secret'
+
+示例 - 解码 base64 文件：
+  sak encode base64 --decode < cert.b64 > cert.der
+
+示例 - 转义查询参数：
+  sak encode url 'a&b=c d'
+`,
+	"decode instead of encoding":                       "解码而不是编码",
+	"Encode and decode hex":                            "编码和解码十六进制",
+	"Escape and unescape URL query components":         "转义和反转义 URL 查询组件",
+	"Encode and decode base64":                         "编码和解码 base64",
+	"encode with the URL-safe alphabet and no padding": "使用 URL 安全字母表且不带填充进行编码",
+	"invalid %s: %v":                                   "无效的 %s：%v",
+
+	// json
+	"Format and query JSON": "格式化和查询 JSON",
+	`Format and query JSON
+
+JSON is read from the file given as the last argument or from stdin. Several
+documents in a row, such as JSON lines, are handled one by one. The order
+of object keys is kept.
+
+Paths are dotted keys with array indexes in brackets or as numbers. Keys
+containing dots are quoted in brackets. Negative indexes count from the end.
+
+Example - pretty-print an API response:
+  curl -s https://api.github.com/repos/spf13/cobra | sak json fmt
+
+Example - print the name of the first item:
+  sak json get '.items[0].name' response.json
+
+Example - read a key containing dots:
+  sak json get '.annotations["app.kubernetes.io/name"]' -r < pod.json
+`: `格式化和查询 JSON
+
+从最后一个参数指定的文件或标准输入读取 JSON。连续的多个文档（如 JSON
+lines）会逐个处理。对象键的顺序保持不变。
+
+路径由点分隔的键组成，数组下标写在方括号中或直接写成数字。包含点的键
+用引号写在方括号中。负数下标从末尾开始计数。
+
+示例 - 美化输出 API 响应：
+  curl -s https://api.github.com/repos/spf13/cobra | sak json fmt
+
+示例 - 输出第一项的名称：
+  sak json get '.items[0].name' response.json
+
+示例 - 读取包含点的键：
+  sak json get '.annotations["app.kubernetes.io/name"]' -r < pod.json
+`,
+	"Pretty-print or minify JSON":          "美化或压缩 JSON",
+	"the indent must be at least 0":        "缩进至少为 0",
+	"remove all insignificant whitespace":  "删除所有无意义的空白",
+	"number of spaces to indent with":      "缩进的空格数",
+	"Print the value at a path":            "输出路径处的值",
+	"print strings without quotes":         "输出字符串时不带引号",
+	"print objects and arrays on one line": "在一行中输出对象和数组",
+	"invalid path %q":                      "无效的路径 %q",
+	"%s not found":                         "未找到 %s",
+
+	// convert
+	"Convert between YAML, JSON and TOML": "在 YAML、JSON 和 TOML 之间转换",
+	`Convert between YAML, JSON and TOML
+
+The input is read from the file or stdin. Its format is taken from --from,
+or else from the extension of the file. The order of the keys is kept,
+except that TOML requires tables to follow the other keys of their parent.
+
+TOML has no null, so documents containing null cannot be converted to it.
+
+Example - convert a YAML file to JSON:
+  sak convert --to json config.yaml
+
+Example - convert TOML on stdin to YAML:
+  sak convert --from toml --to yaml < Cargo.toml
+`: `在 YAML、JSON 和 TOML 之间转换
+
+从文件或标准输入读取输入。输入格式取自 --from，否则取自文件扩展名。键的
+顺序保持不变，但 TOML 要求表位于其父级的其他键之后。
+
+TOML 没有 null，因此包含 null 的文档无法转换为 TOML。
+
+示例 - 将 YAML 文件转换为 JSON：
+  sak convert --to json config.yaml
+
+示例 - 将标准输入中的 TOML 转换为 YAML：
+  sak convert --from toml --to yaml < Cargo.toml
+`,
+	"cannot tell the input format, use --from":          "无法判断输入格式，请使用 --from",
+	"format of the input: yaml, json or toml":           "输入格式：yaml、json 或 toml",
+	"format of the output: yaml, json or toml":          "输出格式：yaml、json 或 toml",
+	"unknown format %q (expected yaml, json or toml)":   "未知格式 %q（应为 yaml、json 或 toml）",
+	"TOML holds a single document, got %d":              "TOML 只能包含一个文档，实际有 %d 个",
+	"%s cannot be represented in JSON":                  "%s 无法用 JSON 表示",
+	"line %d: %w":                                       "第 %d 行：%w",
+	"unexpected end of file":                            "意外的文件结尾",
+	"unexpected %q":                                     "意外的 %q",
+	"expected %s":                                       "应为 %s",
+	"%s is not an array of tables":                      "%s 不是表数组",
+	"duplicate key %s":                                  "重复的键 %s",
+	"expected a key":                                    "应为键",
+	"unterminated string":                               "字符串未结束",
+	"invalid escape \\%c":                               "无效的转义 \\%c",
+	"invalid escape \\%c%s":                             "无效的转义 \\%c%s",
+	"invalid integer %s":                                "无效的整数 %s",
+	"invalid value %q":                                  "无效的值 %q",
+	"%s is not a table":                                 "%s 不是表",
+	"TOML documents must be a mapping at the top level": "TOML 文档的顶层必须是映射",
+	"%s is empty, which TOML cannot represent":          "%s 为空，TOML 无法表示",
+	"%s is null, which TOML cannot represent":           "%s 为 null，TOML 无法表示",
+	"invalid %s value %s":                               "无效的 %s 值 %s",
+	"invalid float %s":                                  "无效的浮点数 %s",
+
+	// csv
+	"Work with CSV files":        "处理 CSV 文件",
+	"Show a CSV file as a table": "以表格显示 CSV 文件",
+	`Show a CSV file as a table
+
+The file is read from stdin if it is -. The delimiter is guessed from the
+first line unless given with --delimiter. Whether the first row is a header
+is guessed as well: it is if all of its cells are distinct, non-empty and
+not numbers. Columns holding only numbers are aligned to the right.
+
+With --output json or yaml, the rows are printed as objects keyed by the
+header.
+
+Example - show the first 20 rows:
+  sak csv view data.csv --limit 20
+
+Example - show two columns, by name and by position:
+  sak csv view data.csv --columns name,3
+
+Example - show a headerless, semicolon-separated file:
+  sak csv view export.csv --header no --delimiter ';'
+`: `以表格显示 CSV 文件
+
+文件为 - 时从标准输入读取。除非用 --delimiter 指定，分隔符根据第一行推测。
+第一行是否为表头也会推测：若其所有单元格互不相同、非空且不是数字，则视为
+表头。只包含数字的列右对齐。
+
+使用 --output json 或 yaml 时，每行输出为以表头为键的对象。
+
+示例 - 显示前 20 行：
+  sak csv view data.csv --limit 20
+
+示例 - 按名称和位置显示两列：
+  sak csv view data.csv --columns name,3
+
+示例 - 显示没有表头、以分号分隔的文件：
+  sak csv view export.csv --header no --delimiter ';'
+`,
+	"unknown header mode %q (expected auto, yes or no)":         "未知的表头模式 %q（应为 auto、yes 或 no）",
+	"showing %d of %d rows":                                     "显示 %d 行，共 %d 行",
+	"comma-separated names or positions of the columns to show": "要显示的列的名称或位置，以逗号分隔",
+	"whether the first row is a header: auto, yes or no":        "第一行是否为表头：auto、yes 或 no",
+	"field delimiter, guessed by default":                       "字段分隔符，默认自动推测",
+	"maximum number of rows to show, 0 for all":                 "最多显示的行数，0 表示全部",
+	"the delimiter must be a single character":                  "分隔符必须是单个字符",
+	"unknown column %q":                                         "未知的列 %q",
+
+	// ts
+	"Convert between Unix timestamps, dates and time zones": "在 Unix 时间戳、日期和时区之间转换",
+	`Convert between Unix timestamps, dates and time zones
+
+The time is "now" (the default), a Unix timestamp in seconds, milliseconds,
+microseconds or nanoseconds, or a date such as 2025-07-16 09:00 or
+2025-07-16T09:00:00+08:00. Dates without a zone are in the local zone.
+
+It is shown as a timestamp, in UTC, in the local zone and in the zones
+given with --in, which take IANA names such as Asia/Shanghai.
+
+Example - show a timestamp as a date:
+  sak ts 1721102400
+
+Example - print the timestamp of a date:
+  sak ts "2025-07-16 09:00" --to-epoch
+
+Example - show the time in Shanghai and New York:
+  sak ts now --in Asia/Shanghai,America/New_York
+`: `在 Unix 时间戳、日期和时区之间转换
+
+时间可以是 "now"（默认）、以秒、毫秒、微秒或纳秒为单位的 Unix 时间戳，或
+2025-07-16 09:00、2025-07-16T09:00:00+08:00 这样的日期。没有时区的日期按
+本地时区处理。
+
+结果以时间戳、UTC、本地时区以及 --in 指定的时区显示，--in 接受
+Asia/Shanghai 这样的 IANA 名称。
+
+示例 - 将时间戳显示为日期：
+  sak ts 1721102400
+
+示例 - 输出日期的时间戳：
+  sak ts "2025-07-16 09:00" --to-epoch
+
+示例 - 显示上海和纽约的时间：
+  sak ts now --in Asia/Shanghai,America/New_York
+`,
+	"unknown time zone %q":                           "未知时区 %q",
+	"only print the Unix timestamp in seconds":       "只输出以秒为单位的 Unix 时间戳",
+	"comma-separated time zones to show the time in": "显示时间所用的时区，以逗号分隔",
+	"Epoch":      "时间戳",
+	"Epoch (ms)": "时间戳（毫秒）",
+	"UTC":        "UTC",
+	"Local":      "本地",
+	"Relative":   "相对时间",
+	"invalid time %q (expected e.g. 1721102400, 2025-07-16 09:00 or 2025-07-16T09:00:00Z)": "无效的时间 %q（应为 1721102400、2025-07-16 09:00 或 2025-07-16T09:00:00Z 等）",
+	"timestamp %q is out of range": "时间戳 %q 超出范围",
+
+	// cal
+	"Show a calendar of a month with notes and habits": "显示带有笔记和习惯的月历",
+	`Show a calendar of a month with notes and habits
+
+Weeks start on Monday and today is highlighted. Days on which notes were
+captured with sak note are marked with •. With --habit, the days on which
+the habit was checked are marked with ✓.
+
+Example - show the current month:
+  sak cal
+
+Example - show July 2025 with the days of a habit:
+  sak cal --month 2025-07 --habit exercise
+`: `显示带有笔记和习惯的月历
+
+每周从周一开始，今天会高亮显示。用 sak note 记录过笔记的日期以 • 标记。
+使用 --habit 时，打卡过该习惯的日期以 ✓ 标记。
+
+示例 - 显示本月：
+  sak cal
+
+示例 - 显示 2025 年 7 月及某个习惯的打卡日期：
+  sak cal --month 2025-07 --habit exercise
+`,
+	"month to show, e.g. 2025-07 or last-month":     "要显示的月份，如 2025-07 或 last-month",
+	"mark the days on which this habit was checked": "标记打卡过该习惯的日期",
+	"January":     "一月",
+	"February":    "二月",
+	"March":       "三月",
+	"April":       "四月",
+	"May":         "五月",
+	"June":        "六月",
+	"July":        "七月",
+	"August":      "八月",
+	"September":   "九月",
+	"October":     "十月",
+	"November":    "十一月",
+	"December":    "十二月",
+	"%[1]s %[2]d": "%[2]d年%[1]s",
+	"notes":       "笔记",
+	"invalid month %q (expected e.g. 2025-07, this-month or last-month)": "无效的月份 %q（应为 2025-07、this-month 或 last-month 等）",
+
+	// weather
+	"Show the current weather and a forecast": "显示当前天气和天气预报",
+	`Show the current weather and a forecast
+
+The city defaults to the weather-city config key. The weather is fetched
+from Open-Meteo, which needs no API key, unless the weather-provider config
+key selects openweathermap, whose API key is set with the weather-key
+config key. Temperatures are in °C and wind speeds in km/h.
+
+With --short, a single line is printed, which suits status bars and
+templates.
+
+Example - show the weather in Shanghai for the next 5 days:
+  sak weather Shanghai --days 5
+
+Example - set the default city and print a single line:
+  sak config set weather-city Berlin
+  sak weather --short
+`: `显示当前天气和天气预报
+
+城市默认为配置项 weather-city。天气从无需 API 密钥的 Open-Meteo 获取，
+除非配置项 weather-provider 选择了 openweathermap，其 API 密钥通过配置项
+weather-key 设置。温度单位为 °C，风速单位为 km/h。
+
+使用 --short 时只输出一行，适合状态栏和模板。
+
+示例 - 显示上海未来 5 天的天气：
+  sak weather Shanghai --days 5
+
+示例 - 设置默认城市并输出一行：
+  sak config set weather-city Berlin
+  sak weather --short
+`,
+	"--days must be between 1 and 5":                                     "--days 必须在 1 到 5 之间",
+	"no city given, pass one or set it with sak config set weather-city": "未指定城市，请传入城市或用 sak config set weather-city 设置",
+	"number of days to forecast, from 1 to 5":                            "预报的天数，1 到 5",
+	"print the weather on a single line":                                 "在一行中输出天气",
+	"%[1]s: %[2]s, %[3]s":                                                "%[1]s：%[2]s，%[3]s",
+	"Conditions":                                                         "天气",
+	"Temperature":                                                        "温度",
+	"Feels like":                                                         "体感温度",
+	"Humidity":                                                           "湿度",
+	"Wind":                                                               "风速",
+	"DATE":                                                               "日期",
+	"CONDITIONS":                                                         "天气",
+	"MIN":                                                                "最低",
+	"MAX":                                                                "最高",
+	"PRECIPITATION":                                                      "降水概率",
+	"city %q not found":                                                  "未找到城市 %q",
+	"Clear sky":                                                          "晴",
+	"Mainly clear":                                                       "晴间少云",
+	"Partly cloudy":                                                      "多云",
+	"Overcast":                                                           "阴",
+	"Fog":                                                                "雾",
+	"Drizzle":                                                            "毛毛雨",
+	"Rain":                                                               "雨",
+	"Heavy rain":                                                         "大雨",
+	"Snow":                                                               "雪",
+	"Heavy snow":                                                         "大雪",
+	"Thunderstorm":                                                       "雷暴",
+	"Thunderstorm with hail":                                             "雷暴伴有冰雹",
+	"Unknown":                                                            "未知",
+	"unknown weather provider %q (expected open-meteo or openweathermap)":                     "未知的天气服务 %q（应为 open-meteo 或 openweathermap）",
+	"the openweathermap provider requires an API key, set it with sak config set weather-key": "openweathermap 服务需要 API 密钥，请用 sak config set weather-key 设置",
+	"parse response of %s: %w": "解析 %s 的响应：%w",
+	"GET %s: %w":               "请求 %s：%w",
+
+	// ip
+	"Show the local and public IP addresses": "显示本地和公网 IP 地址",
+	`Show the local and public IP addresses
+
+The addresses of the interfaces that are up are listed, except loopback
+ones unless --all is given. The public address is asked to the service set
+with the ip-lookup config key, https://api.ipify.org by default, which must
+answer it as plain text. If it cannot be reached, a warning is printed and
+the local addresses are still shown.
+
+Example - show the addresses as JSON:
+  sak ip --json
+
+Example - print only the public address:
+  sak ip --public
+
+Example - use another lookup service:
+  sak config set ip-lookup https://ifconfig.me/ip
+`: `显示本地和公网 IP 地址
+
+列出已启用网络接口的地址，除非指定 --all，否则不含回环接口。公网地址向配置项
+ip-lookup 设置的服务查询，默认为 https://api.ipify.org，该服务须以纯文本返回
+地址。如果无法访问该服务，会输出警告，并仍然显示本地地址。
+
+示例 - 以 JSON 显示地址：
+  sak ip --json
+
+示例 - 只输出公网地址：
+  sak ip --public
+
+示例 - 使用其他查询服务：
+  sak config set ip-lookup https://ifconfig.me/ip
+`,
+	"get public IP address: %w":                                        "获取公网 IP 地址：%w",
+	"warning: get public IP address: %v":                               "警告：获取公网 IP 地址：%v",
+	"include loopback interfaces and interfaces that are down":         "包括回环接口和未启用的接口",
+	"only show the local addresses, without looking up the public one": "只显示本地地址，不查询公网地址",
+	"only show the public address":                                     "只显示公网地址",
+	"print JSON, the same as --output json":                            "输出 JSON，等同于 --output json",
+	"INTERFACE":                                                        "接口",
+	"FAMILY":                                                           "类型",
+	"ADDRESS":                                                          "地址",
+	"MAC":                                                              "MAC",
+	"Public:":                                                          "公网：",
+	"expected an http or https URL":                                    "应为 http 或 https URL",
+	"%s did not answer an IP address: %q":                              "%s 返回的不是 IP 地址：%q",
+	"--json cannot be combined with --output":                          "--json 不能与 --output 同时使用",
+
+	// port
+	"Check and list TCP ports":                   "检查和列出 TCP 端口",
+	"Check whether TCP ports accept connections": "检查 TCP 端口是否接受连接",
+	`Check whether TCP ports accept connections
+
+Each address is checked by opening a TCP connection to it, which is closed
+right away. A bare port is checked on localhost. sak exits with an error if
+any of the ports does not accept the connection within --timeout.
+
+Example - check a web server:
+  sak port check example.com:443
+
+Example - check local ports with a shorter timeout:
+  sak port check 5432 6379 --timeout 500ms
+`: `检查 TCP 端口是否接受连接
+
+通过建立 TCP 连接检查每个地址，连接建立后立即关闭。只给出端口时检查
+localhost。如果有端口未在 --timeout 内接受连接，sak 以错误退出。
+
+示例 - 检查网页服务器：
+  sak port check example.com:443
+
+示例 - 以更短的超时检查本地端口：
+  sak port check 5432 6379 --timeout 500ms
+`,
+	"--timeout must be positive":              "--timeout 必须为正数",
+	"%d of %d ports are not open":             "%d 个端口未开放（共 %d 个）",
+	"how long to wait for each connection":    "每个连接的等待时间",
+	"invalid address %q (expected host:port)": "无效的地址 %q（应为 host:port）",
+	"STATUS":                       "状态",
+	"DETAIL":                       "详情",
+	"closed":                       "关闭",
+	"open":                         "开放",
+	"List the listening TCP ports": "列出监听中的 TCP 端口",
+	`List the listening TCP ports
+
+The ports are read from /proc, so this is only supported on Linux. The
+process owning a port is only shown if it may be inspected, which usually
+means it runs as the current user; run with sudo to see all of them.
+
+Example - list the listening ports:
+  sak port ls
+`: `列出监听中的 TCP 端口
+
+端口从 /proc 读取，因此只支持 Linux。只有可以查看的进程才会显示为端口的
+所属进程，通常是以当前用户运行的进程；用 sudo 运行可以看到全部。
+
+示例 - 列出监听中的端口：
+  sak port ls
+`,
+	"PROTO":                                "协议",
+	"PORT":                                 "端口",
+	"PID":                                  "PID",
+	"PROCESS":                              "进程",
+	"listing ports is not supported on %s": "%s 上不支持列出端口",
+	"parse /proc/net/%s: %w":               "解析 /proc/net/%s：%w",
+	"invalid address %q":                   "无效的地址 %q",
+}
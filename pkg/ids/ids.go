@@ -0,0 +1,143 @@
+package ids
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"math/bits"
+	"time"
+
+	"github.com/hezhizhen/sak/pkg/exit"
+	"github.com/hezhizhen/sak/pkg/i18n"
+)
+
+// Alphabets of nano IDs
+const (
+	// NanoAlphabet is the default alphabet of nano IDs, which is URL-safe
+	NanoAlphabet = "_-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	NanoLower    = "0123456789abcdefghijklmnopqrstuvwxyz"
+	NanoUpper    = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+)
+
+// crockford is the base32 alphabet of ULIDs, without I, L, O and U
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// UUID is a 128-bit universally unique identifier
+type UUID [16]byte
+
+// NewUUID returns a random (version 4) UUID
+func NewUUID() (UUID, error) {
+	var u UUID
+	if _, err := rand.Read(u[:]); err != nil {
+		return u, err
+	}
+	u.setVersion(4)
+	return u, nil
+}
+
+// NewUUIDv7 returns a UUID that starts with the Unix time in milliseconds,
+// so UUIDs sort by creation time
+func NewUUIDv7(now time.Time) (UUID, error) {
+	var u UUID
+	if _, err := rand.Read(u[6:]); err != nil {
+		return u, err
+	}
+	putMillis(u[:6], now)
+	u.setVersion(7)
+	return u, nil
+}
+
+func (u *UUID) setVersion(v byte) {
+	u[6] = u[6]&0x0f | v<<4
+	// RFC 4122 variant
+	u[8] = u[8]&0x3f | 0x80
+}
+
+// String returns the canonical form of u, such as
+// 0f8fad5b-d9cb-469f-a165-70867728950e
+func (u UUID) String() string {
+	buf := make([]byte, 36)
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf)
+}
+
+// Base64 returns u as 22 characters of unpadded URL-safe base64
+func (u UUID) Base64() string {
+	return base64.RawURLEncoding.EncodeToString(u[:])
+}
+
+// NewULID returns a universally unique lexicographically sortable
+// identifier: 26 characters of Crockford's base32 holding the time in
+// milliseconds followed by 80 random bits
+func NewULID(now time.Time) (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[6:]); err != nil {
+		return "", err
+	}
+	putMillis(b[:6], now)
+
+	hi, lo := binary.BigEndian.Uint64(b[:8]), binary.BigEndian.Uint64(b[8:])
+	out := make([]byte, 26)
+	for i := 25; i >= 0; i-- {
+		out[i] = crockford[lo&31]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(out), nil
+}
+
+// putMillis writes the Unix time of t in milliseconds as 48 bits to b
+func putMillis(b []byte, t time.Time) {
+	ms := uint64(t.UnixMilli())
+	for i := 5; i >= 0; i-- {
+		b[i] = byte(ms)
+		ms >>= 8
+	}
+}
+
+// NewNanoID returns an ID of size characters drawn from alphabet
+func NewNanoID(size int, alphabet string) (string, error) {
+	if size < 1 {
+		return "", exit.New(exit.Usage, i18n.Errorf("the size must be at least 1"))
+	}
+	if len(alphabet) < 2 || !isASCII(alphabet) {
+		return "", exit.New(exit.Usage, i18n.Errorf("the alphabet must have at least 2 ASCII characters"))
+	}
+
+	// random bytes are masked to the next power of two and those beyond the
+	// alphabet are dropped, so every character is equally likely
+	mask := byte(1<<bits.Len(uint(len(alphabet)-1)) - 1)
+	id := make([]byte, 0, size)
+	buf := make([]byte, size*2)
+	for {
+		if _, err := rand.Read(buf); err != nil {
+			return "", err
+		}
+		for _, b := range buf {
+			if i := int(b & mask); i < len(alphabet) {
+				id = append(id, alphabet[i])
+				if len(id) == size {
+					return string(id), nil
+				}
+			}
+		}
+	}
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
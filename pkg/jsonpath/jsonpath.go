@@ -0,0 +1,153 @@
+package jsonpath
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/hezhizhen/sak/pkg/exit"
+	"github.com/hezhizhen/sak/pkg/i18n"
+)
+
+// Segment is a step of a path: an object key, an array index if IsIndex is
+// set, or both for numeric keys such as the 0 of items.0. Negative indexes
+// count from the end.
+type Segment struct {
+	Key     string
+	Index   int
+	IsIndex bool
+}
+
+// Parse splits a path such as .items[0].name, items.0.name or
+// ["key.with.dots"] into its segments. An empty path or "." selects the
+// whole document.
+func Parse(path string) ([]Segment, error) {
+	var segments []Segment
+	invalid := func() ([]Segment, error) {
+		return nil, exit.New(exit.Usage, i18n.Errorf("invalid path %q", path))
+	}
+
+	s := strings.TrimPrefix(path, ".")
+	for s != "" {
+		switch {
+		case strings.HasPrefix(s, `["`):
+			end := strings.Index(s, `"]`)
+			if end < 0 {
+				return invalid()
+			}
+			key, err := strconv.Unquote(s[1 : end+1])
+			if err != nil {
+				return invalid()
+			}
+			segments = append(segments, Segment{Key: key})
+			s = s[end+2:]
+		case s[0] == '[':
+			end := strings.IndexByte(s, ']')
+			if end < 0 {
+				return invalid()
+			}
+			index, err := strconv.Atoi(strings.TrimSpace(s[1:end]))
+			if err != nil {
+				return invalid()
+			}
+			segments = append(segments, Segment{Index: index, IsIndex: true})
+			s = s[end+1:]
+		default:
+			end := strings.IndexAny(s, ".[")
+			if end < 0 {
+				end = len(s)
+			}
+			if end == 0 {
+				return invalid()
+			}
+			key := s[:end]
+			// a numeric key also selects an array element, as in items.0
+			if index, err := strconv.Atoi(key); err == nil {
+				segments = append(segments, Segment{Key: key, Index: index, IsIndex: true})
+			} else {
+				segments = append(segments, Segment{Key: key})
+			}
+			s = s[end:]
+		}
+		if strings.HasPrefix(s, ".") {
+			s = s[1:]
+			if s == "" {
+				return invalid()
+			}
+		}
+	}
+	return segments, nil
+}
+
+// Get returns the value at path in the JSON document data, keeping the
+// order of the keys of objects
+func Get(data []byte, path []Segment) (json.RawMessage, error) {
+	value := json.RawMessage(bytes.TrimSpace(data))
+	for i, seg := range path {
+		notFound := func() (json.RawMessage, error) {
+			return nil, exit.New(exit.NotFound, i18n.Errorf("%s not found", Format(path[:i+1])))
+		}
+
+		switch firstByte(value) {
+		case '{':
+			if seg.IsIndex && seg.Key == "" {
+				return notFound()
+			}
+			var object map[string]json.RawMessage
+			if err := json.Unmarshal(value, &object); err != nil {
+				return nil, err
+			}
+			next, ok := object[seg.Key]
+			if !ok {
+				return notFound()
+			}
+			value = next
+		case '[':
+			if !seg.IsIndex {
+				return notFound()
+			}
+			var array []json.RawMessage
+			if err := json.Unmarshal(value, &array); err != nil {
+				return nil, err
+			}
+			index := seg.Index
+			if index < 0 {
+				index += len(array)
+			}
+			if index < 0 || index >= len(array) {
+				return notFound()
+			}
+			value = array[index]
+		default:
+			return notFound()
+		}
+	}
+	return value, nil
+}
+
+// Format writes path in the dotted notation
+func Format(path []Segment) string {
+	var b strings.Builder
+	for _, seg := range path {
+		switch {
+		case seg.IsIndex:
+			b.WriteString("[" + strconv.Itoa(seg.Index) + "]")
+		case seg.Key == "" || strings.ContainsAny(seg.Key, `.[]"`):
+			b.WriteString("[" + strconv.Quote(seg.Key) + "]")
+		default:
+			b.WriteString("." + seg.Key)
+		}
+	}
+	if b.Len() == 0 {
+		return "."
+	}
+	return b.String()
+}
+
+func firstByte(value json.RawMessage) byte {
+	if len(value) == 0 {
+		return 0
+	}
+	return value[0]
+}
@@ -0,0 +1,157 @@
+package jsonpath
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hezhizhen/sak/pkg/exit"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		path string
+		want []Segment
+	}{
+		{"", nil},
+		{".", nil},
+		{".name", []Segment{{Key: "name"}}},
+		{"name", []Segment{{Key: "name"}}},
+		{".items[0].name", []Segment{{Key: "items"}, {Index: 0, IsIndex: true}, {Key: "name"}}},
+		{"items.0.name", []Segment{{Key: "items"}, {Key: "0", Index: 0, IsIndex: true}, {Key: "name"}}},
+		{"items[-1]", []Segment{{Key: "items"}, {Index: -1, IsIndex: true}}},
+		{"items.-2", []Segment{{Key: "items"}, {Key: "-2", Index: -2, IsIndex: true}}},
+		{"[ 3 ]", []Segment{{Index: 3, IsIndex: true}}},
+		{`["key.with.dots"]`, []Segment{{Key: "key.with.dots"}}},
+		{`.a["b c"].d`, []Segment{{Key: "a"}, {Key: "b c"}, {Key: "d"}}},
+		{`[""]`, []Segment{{Key: ""}}},
+		{`["tab\there"]`, []Segment{{Key: "tab\there"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := Parse(tt.path)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, path := range []string{
+		"a.",
+		".a.",
+		"..",
+		"a..b",
+		"a[",
+		"a[x]",
+		"a[]",
+		"a[99999999999999999999]",
+		`["unterminated`,
+		`["bad \q"]`,
+	} {
+		t.Run(path, func(t *testing.T) {
+			_, err := Parse(path)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if code := exit.Code(err); code != exit.Usage {
+				t.Errorf("got exit code %d (%v), want %d", code, err, exit.Usage)
+			}
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	doc := []byte(`{
+  "name": "sak",
+  "items": [{"id": 1}, {"id": 2}, {"id": 3}],
+  "key.with.dots": true,
+  "0": "zero",
+  "nested": {"z": 1, "a": 2}
+}`)
+	tests := []struct {
+		path string
+		want string
+	}{
+		{".", string(doc)},
+		{".name", `"sak"`},
+		{".items[0].id", "1"},
+		{"items.1.id", "2"},
+		{"items[-1].id", "3"},
+		{"items[-3].id", "1"},
+		{`["key.with.dots"]`, "true"},
+		{".0", `"zero"`},
+		{".nested", `{"z": 1, "a": 2}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			path, err := Parse(tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := Get(doc, path)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetNotFound(t *testing.T) {
+	doc := []byte(`{"items": [1, 2, 3], "name": "sak"}`)
+	tests := []struct {
+		path    string
+		message string
+	}{
+		{".missing", ".missing not found"},
+		{"items[3]", ".items[3] not found"},
+		{"items[-4]", ".items[-4] not found"},
+		{"items.name", ".items.name not found"},
+		{"[0]", "[0] not found"},
+		{".name.first", ".name.first not found"},
+		{".items[0].x", ".items[0].x not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			path, err := Parse(tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = Get(doc, path)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if code := exit.Code(err); code != exit.NotFound {
+				t.Errorf("got exit code %d (%v), want %d", code, err, exit.NotFound)
+			}
+			if err.Error() != tt.message {
+				t.Errorf("got %q, want %q", err.Error(), tt.message)
+			}
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := map[string]string{
+		"":                  ".",
+		".items[0].name":    ".items[0].name",
+		"items.0":           ".items[0]",
+		`["key.with.dots"]`: `["key.with.dots"]`,
+		`[""]`:              `[""]`,
+	}
+	for path, want := range tests {
+		segments, err := Parse(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := Format(segments); got != want {
+			t.Errorf("Format(Parse(%q)) = %q, want %q", path, got, want)
+		}
+	}
+}
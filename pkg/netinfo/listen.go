@@ -0,0 +1,137 @@
+package netinfo
+
+import (
+	"bufio"
+	"encoding/hex"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hezhizhen/sak/pkg/exit"
+	"github.com/hezhizhen/sak/pkg/i18n"
+)
+
+// Listener is a TCP socket listening for connections
+type Listener struct {
+	Protocol string `json:"protocol" yaml:"protocol"`
+	Address  string `json:"address" yaml:"address"`
+	Port     int    `json:"port" yaml:"port"`
+	// PID and Process are only known for the sockets of processes that may
+	// be inspected, usually those of the current user
+	PID     int    `json:"pid,omitempty" yaml:"pid,omitempty"`
+	Process string `json:"process,omitempty" yaml:"process,omitempty"`
+}
+
+// tcpListen is the state of a listening socket in /proc/net/tcp
+const tcpListen = "0A"
+
+// Listeners lists the listening TCP sockets, sorted by port. It reads /proc
+// and so is only supported on Linux.
+func Listeners() ([]Listener, error) {
+	if runtime.GOOS != "linux" {
+		return nil, exit.New(exit.ToolMissing, i18n.Errorf("listing ports is not supported on %s", runtime.GOOS))
+	}
+
+	list := []Listener{}
+	inodes := map[string]int{}
+	for _, protocol := range []string{"tcp", "tcp6"} {
+		f, err := os.Open(filepath.Join("/proc/net", protocol))
+		if os.IsNotExist(err) {
+			// IPv6 is disabled
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		scanner := bufio.NewScanner(f)
+		// the first line holds the names of the fields
+		scanner.Scan()
+		for scanner.Scan() {
+			// sl local_address rem_address st tx_queue:rx_queue tr:tm->when
+			// retrnsmt uid timeout inode
+			fields := strings.Fields(scanner.Text())
+			if len(fields) < 10 || fields[3] != tcpListen {
+				continue
+			}
+			ip, port, err := parseProcAddress(fields[1])
+			if err != nil {
+				f.Close()
+				return nil, exit.New(exit.ParseError, i18n.Errorf("parse /proc/net/%s: %w", protocol, err))
+			}
+			inodes[fields[9]] = len(list)
+			list = append(list, Listener{Protocol: protocol, Address: ip.String(), Port: port})
+		}
+		err = scanner.Err()
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	findProcesses(list, inodes)
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].Port != list[j].Port {
+			return list[i].Port < list[j].Port
+		}
+		return list[i].Protocol < list[j].Protocol
+	})
+	return list, nil
+}
+
+// parseProcAddress parses an address of /proc/net/tcp, e.g. 0100007F:1F90.
+// The IP is written as 32-bit words in host byte order, which is little
+// endian on the architectures Linux mostly runs on.
+func parseProcAddress(s string) (net.IP, int, error) {
+	hexIP, hexPort, ok := strings.Cut(s, ":")
+	if !ok {
+		return nil, 0, i18n.Errorf("invalid address %q", s)
+	}
+	ip, err := hex.DecodeString(hexIP)
+	if err != nil || (len(ip) != net.IPv4len && len(ip) != net.IPv6len) {
+		return nil, 0, i18n.Errorf("invalid address %q", s)
+	}
+	for word := 0; word < len(ip); word += 4 {
+		ip[word], ip[word+1], ip[word+2], ip[word+3] = ip[word+3], ip[word+2], ip[word+1], ip[word]
+	}
+	port, err := strconv.ParseUint(hexPort, 16, 16)
+	if err != nil {
+		return nil, 0, i18n.Errorf("invalid address %q", s)
+	}
+	return net.IP(ip), int(port), nil
+}
+
+// findProcesses fills in the processes owning the sockets, whose indexes in
+// list are keyed by inode. Processes that may not be inspected are skipped.
+func findProcesses(list []Listener, inodes map[string]int) {
+	dirs, err := os.ReadDir("/proc")
+	if err != nil {
+		return
+	}
+	for _, dir := range dirs {
+		pid, err := strconv.Atoi(dir.Name())
+		if err != nil {
+			continue
+		}
+		fds, err := os.ReadDir(filepath.Join("/proc", dir.Name(), "fd"))
+		if err != nil {
+			continue
+		}
+		for _, fd := range fds {
+			link, err := os.Readlink(filepath.Join("/proc", dir.Name(), "fd", fd.Name()))
+			if err != nil || !strings.HasPrefix(link, "socket:[") {
+				continue
+			}
+			index, ok := inodes[strings.TrimSuffix(strings.TrimPrefix(link, "socket:["), "]")]
+			if !ok || list[index].PID != 0 {
+				continue
+			}
+			list[index].PID = pid
+			if comm, err := os.ReadFile(filepath.Join("/proc", dir.Name(), "comm")); err == nil {
+				list[index].Process = strings.TrimSpace(string(comm))
+			}
+		}
+	}
+}
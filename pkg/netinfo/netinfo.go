@@ -0,0 +1,90 @@
+package netinfo
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/hezhizhen/sak/pkg/exit"
+	"github.com/hezhizhen/sak/pkg/i18n"
+)
+
+// DefaultLookupURL is the service PublicIP asks by default
+const DefaultLookupURL = "https://api.ipify.org"
+
+// Address is an address of a network interface
+type Address struct {
+	Interface string `json:"interface" yaml:"interface"`
+	// Address is in CIDR notation, e.g. 192.168.1.10/24
+	Address string `json:"address" yaml:"address"`
+	// Family is IPv4 or IPv6
+	Family string `json:"family" yaml:"family"`
+	MAC    string `json:"mac,omitempty" yaml:"mac,omitempty"`
+}
+
+// Addresses lists the addresses of the interfaces that are up, except the
+// loopback ones unless all is set, in which case every interface is listed
+func Addresses(all bool) ([]Address, error) {
+	interfaces, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+	list := []Address{}
+	for _, iface := range interfaces {
+		if !all && (iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0) {
+			continue
+		}
+		addrs, err := iface.Addrs()
+		if err != nil {
+			return nil, err
+		}
+		for _, addr := range addrs {
+			ipNet, ok := addr.(*net.IPNet)
+			if !ok {
+				continue
+			}
+			family := "IPv6"
+			if ipNet.IP.To4() != nil {
+				family = "IPv4"
+			}
+			list = append(list, Address{
+				Interface: iface.Name,
+				Address:   ipNet.String(),
+				Family:    family,
+				MAC:       iface.HardwareAddr.String(),
+			})
+		}
+	}
+	return list, nil
+}
+
+// PublicIP asks the service at lookupURL for the public address of this
+// host, which it must answer as plain text
+func PublicIP(ctx context.Context, lookupURL string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, lookupURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("User-Agent", "sak")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", i18n.Errorf("GET %s: %s", lookupURL, resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if err != nil {
+		return "", err
+	}
+	answer := strings.TrimSpace(string(data))
+	ip := net.ParseIP(answer)
+	if ip == nil {
+		return "", exit.New(exit.ParseError, i18n.Errorf("%s did not answer an IP address: %q", lookupURL, answer))
+	}
+	return ip.String(), nil
+}
@@ -0,0 +1,105 @@
+package note
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hezhizhen/sak/pkg/config"
+	"github.com/hezhizhen/sak/pkg/dryrun"
+)
+
+// timeLayout is the format of the note timestamps in the inbox
+const timeLayout = "2006-01-02 15:04"
+
+// header starts a new inbox file
+const header = "# Inbox\n\n"
+
+// Note is a captured one-liner
+type Note struct {
+	Time time.Time `json:"time" yaml:"time"`
+	Text string    `json:"text" yaml:"text"`
+}
+
+// Path returns the location of the inbox, inbox.md in the data directory
+func Path() (string, error) {
+	dir, err := config.DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "inbox.md"), nil
+}
+
+// Append adds a note at the end of the inbox as a Markdown list item,
+// creating the inbox if needed
+func Append(ctx context.Context, n Note) error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	text := strings.Join(strings.Fields(n.Text), " ")
+	line := "- " + n.Time.Format(timeLayout) + " " + text + "\n"
+	if dryrun.Enabled(ctx) {
+		dryrun.Report(ctx, "append to %s: %s", path, strings.TrimSpace(line))
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if info, err := f.Stat(); err == nil && info.Size() == 0 {
+		line = header + line
+	}
+	if _, err := f.WriteString(line); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Load returns the notes of the inbox, oldest first. Lines that are not
+// notes, such as headings or text added by hand, are skipped.
+func Load() ([]Note, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var notes []Note
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if n, ok := parse(scanner.Text()); ok {
+			notes = append(notes, n)
+		}
+	}
+	return notes, scanner.Err()
+}
+
+// parse reads a "- 2006-01-02 15:04 text" line
+func parse(line string) (Note, bool) {
+	rest := strings.TrimPrefix(line, "- ")
+	if rest == line || len(rest) < len(timeLayout) {
+		return Note{}, false
+	}
+	t, err := time.ParseInLocation(timeLayout, rest[:len(timeLayout)], time.Local)
+	if err != nil {
+		return Note{}, false
+	}
+	return Note{Time: t, Text: strings.TrimSpace(rest[len(timeLayout):])}, true
+}
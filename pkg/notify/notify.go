@@ -0,0 +1,30 @@
+package notify
+
+import (
+	"os/exec"
+	"runtime"
+	"strconv"
+
+	"github.com/hezhizhen/sak/pkg/exit"
+	"github.com/hezhizhen/sak/pkg/i18n"
+)
+
+// Send shows a desktop notification, with osascript on macOS and
+// notify-send elsewhere
+func Send(title, message string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		// strconv.Quote produces a valid AppleScript string for plain text
+		script := "display notification " + strconv.Quote(message) + " with title " + strconv.Quote(title)
+		cmd = exec.Command("osascript", "-e", script)
+	case "windows":
+		return exit.New(exit.ToolMissing, i18n.Errorf("desktop notifications are not supported on %s", runtime.GOOS))
+	default:
+		cmd = exec.Command("notify-send", "--app-name=sak", title, message)
+	}
+	if err := cmd.Run(); err != nil {
+		return i18n.Errorf("send notification: %w", err)
+	}
+	return nil
+}
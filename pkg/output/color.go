@@ -0,0 +1,69 @@
+package output
+
+import (
+	"os"
+
+	"github.com/hezhizhen/sak/pkg/i18n"
+)
+
+// Color is an ANSI SGR color code
+type Color string
+
+const (
+	Bold   Color = "1"
+	Red    Color = "31"
+	Green  Color = "32"
+	Yellow Color = "33"
+	Blue   Color = "34"
+	Cyan   Color = "36"
+)
+
+// Color modes accepted by the color config key
+const (
+	ColorAuto   = "auto"
+	ColorAlways = "always"
+	ColorNever  = "never"
+)
+
+// ParseColorMode validates a color mode, the empty string meaning auto
+func ParseColorMode(s string) (string, error) {
+	switch s {
+	case "", ColorAuto:
+		return ColorAuto, nil
+	case ColorAlways, ColorNever:
+		return s, nil
+	}
+	return "", i18n.Errorf("unknown color mode %q (expected auto, always or never)", s)
+}
+
+// ColorEnabled decides whether ANSI colors should be written to f. In auto
+// mode colors are used only when f is a terminal and $NO_COLOR is not set.
+func ColorEnabled(mode string, f *os.File) bool {
+	switch mode {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+	return IsTerminal(f)
+}
+
+// IsTerminal reports whether f is connected to a terminal
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// Colorize wraps s in the given color if colors are enabled
+func (o Options) Colorize(color Color, s string) string {
+	if !o.Color || color == "" || s == "" {
+		return s
+	}
+	return "\x1b[" + string(color) + "m" + s + "\x1b[0m"
+}
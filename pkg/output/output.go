@@ -0,0 +1,79 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/hezhizhen/sak/pkg/exit"
+	"github.com/hezhizhen/sak/pkg/i18n"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format is the format command results are rendered in
+type Format string
+
+const (
+	// Table renders human readable text
+	Table Format = "table"
+	// JSON renders indented JSON
+	JSON Format = "json"
+	// YAML renders YAML
+	YAML Format = "yaml"
+	// Markdown renders tables as Markdown tables
+	Markdown Format = "markdown"
+)
+
+// Formats lists the supported output formats
+var Formats = []Format{Table, JSON, YAML, Markdown}
+
+// ParseFormat validates a format name
+func ParseFormat(s string) (Format, error) {
+	for _, f := range Formats {
+		if string(f) == s {
+			return f, nil
+		}
+	}
+	return "", exit.New(exit.Usage, i18n.Errorf("unknown output format %q (expected table, json, yaml or markdown)", s))
+}
+
+// Options controls how results are rendered
+type Options struct {
+	Format Format
+	// Color enables ANSI colors in table output
+	Color bool
+	// Border draws borders around tables
+	Border bool
+	// Theme colors the roles of table output, DefaultTheme if nil
+	Theme Theme
+}
+
+// Tabler is implemented by results that can be rendered as human readable text
+type Tabler interface {
+	WriteTable(w io.Writer, opts Options) error
+}
+
+// Render writes v to w in the format selected by opts. Table and Markdown
+// output require v to implement Tabler, JSON and YAML output use the struct
+// tags of v.
+func Render(w io.Writer, opts Options, v interface{}) error {
+	switch opts.Format {
+	case JSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case YAML:
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+		return enc.Close()
+	default:
+		t, ok := v.(Tabler)
+		if !ok {
+			return i18n.Errorf("table output is not supported for %T", v)
+		}
+		return t.WriteTable(w, opts)
+	}
+}
@@ -0,0 +1,108 @@
+package output
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/hezhizhen/sak/pkg/i18n"
+)
+
+// Role is what a piece of colored output means; the theme decides its color
+type Role string
+
+const (
+	// Heading is used for table headers
+	Heading Role = "heading"
+	// Success marks things that are fine or active
+	Success Role = "success"
+	// Warn marks things that need attention
+	Warn Role = "warn"
+	// Error marks failures
+	Error Role = "error"
+	// Accent highlights an item among others
+	Accent Role = "accent"
+)
+
+// Roles lists the themeable roles
+var Roles = []Role{Heading, Success, Warn, Error, Accent}
+
+// Theme maps roles to colors
+type Theme map[Role]Color
+
+// DefaultTheme holds the colors of roles a theme doesn't set
+var DefaultTheme = Theme{
+	Heading: Bold,
+	Success: Green,
+	Warn:    Yellow,
+	Error:   Red,
+	Accent:  Cyan,
+}
+
+// Color returns the color of role in t, or in DefaultTheme if t doesn't set it
+func (t Theme) Color(role Role) Color {
+	if color, ok := t[role]; ok {
+		return color
+	}
+	return DefaultTheme[role]
+}
+
+// colorNames are the color names accepted by ParseColor
+var colorNames = map[string]Color{
+	"bold":      Bold,
+	"dim":       "2",
+	"underline": "4",
+	"black":     "30",
+	"red":       Red,
+	"green":     Green,
+	"yellow":    Yellow,
+	"blue":      Blue,
+	"magenta":   "35",
+	"cyan":      Cyan,
+	"white":     "37",
+}
+
+// ParseColor parses a color: a name such as "green", "bright-red" or
+// "bold", several names joined with "+" such as "bold+red", or raw ANSI SGR
+// parameters such as "1;35". The empty string and "none" disable the color.
+func ParseColor(s string) (Color, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" || s == "none" {
+		return "", nil
+	}
+
+	if isSGR(s) {
+		return Color(s), nil
+	}
+	var codes []string
+	for _, name := range strings.Split(s, "+") {
+		bright := strings.HasPrefix(name, "bright-")
+		color, ok := colorNames[strings.TrimPrefix(name, "bright-")]
+		if !ok {
+			return "", i18n.Errorf("unknown color %q (expected a name such as green, bright-red or bold, or ANSI codes such as 1;35)", s)
+		}
+		if bright {
+			n, err := strconv.Atoi(string(color))
+			if err != nil || n < 30 || n > 37 {
+				return "", i18n.Errorf("unknown color %q (expected a name such as green, bright-red or bold, or ANSI codes such as 1;35)", s)
+			}
+			color = Color(strconv.Itoa(n + 60))
+		}
+		codes = append(codes, string(color))
+	}
+	return Color(strings.Join(codes, ";")), nil
+}
+
+// isSGR reports whether s is a list of numeric SGR parameters
+func isSGR(s string) bool {
+	for _, code := range strings.Split(s, ";") {
+		if _, err := strconv.ParseUint(code, 10, 8); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Style colors s with the theme color of role if colors are enabled
+func (o Options) Style(role Role, s string) string {
+	return o.Colorize(o.Theme.Color(role), s)
+}
@@ -0,0 +1,102 @@
+package passgen
+
+import (
+	"crypto/rand"
+	_ "embed"
+	"math"
+	"math/big"
+	"strings"
+
+	"github.com/hezhizhen/sak/pkg/exit"
+	"github.com/hezhizhen/sak/pkg/i18n"
+)
+
+// Character classes of passwords
+const (
+	Lower   = "abcdefghijklmnopqrstuvwxyz"
+	Upper   = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	Digits  = "0123456789"
+	Symbols = "!#$%&()*+,-./:;<=>?@[]^_{}~"
+)
+
+// wordList holds 1296 short English words, one per line, so a word can also
+// be picked by rolling four dice
+//
+//go:embed words.txt
+var wordList string
+
+var words = strings.Fields(wordList)
+
+// Password returns a random password of length characters drawn from the
+// classes, containing at least one character of each class. It also returns
+// the entropy of the password in bits.
+func Password(length int, classes ...string) (string, float64, error) {
+	if length < len(classes) {
+		return "", 0, exit.New(exit.Usage, i18n.Errorf("the length must be at least %d", len(classes)))
+	}
+	charset := strings.Join(classes, "")
+
+	for {
+		password := make([]byte, length)
+		for i := range password {
+			n, err := pick(len(charset))
+			if err != nil {
+				return "", 0, err
+			}
+			password[i] = charset[n]
+		}
+		// redrawing keeps the passwords that contain every class equally likely
+		if containsAll(string(password), classes) {
+			return string(password), float64(length) * math.Log2(float64(len(charset))), nil
+		}
+	}
+}
+
+// Passphrase returns count random words joined by sep and the entropy of the
+// passphrase in bits
+func Passphrase(count int, sep string) (string, float64, error) {
+	if count < 1 {
+		return "", 0, exit.New(exit.Usage, i18n.Errorf("the number of words must be at least 1"))
+	}
+	chosen := make([]string, count)
+	for i := range chosen {
+		n, err := pick(len(words))
+		if err != nil {
+			return "", 0, err
+		}
+		chosen[i] = words[n]
+	}
+	return strings.Join(chosen, sep), float64(count) * math.Log2(float64(len(words))), nil
+}
+
+// pick returns a uniformly random number in [0, n)
+func pick(n int) (int, error) {
+	v, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, err
+	}
+	return int(v.Int64()), nil
+}
+
+func containsAll(s string, classes []string) bool {
+	for _, class := range classes {
+		if !strings.ContainsAny(s, class) {
+			return false
+		}
+	}
+	return true
+}
+
+// Strength describes entropy in words
+func Strength(bits float64) string {
+	switch {
+	case bits < 40:
+		return i18n.T("weak")
+	case bits < 64:
+		return i18n.T("fair")
+	case bits < 100:
+		return i18n.T("strong")
+	default:
+		return i18n.T("very strong")
+	}
+}
@@ -0,0 +1,67 @@
+package plugin
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// Prefix is the prefix of plugin executables: `sak foo` runs `sak-foo`
+const Prefix = "sak-"
+
+// Plugin is an external executable extending sak
+type Plugin struct {
+	Name string `json:"name" yaml:"name"`
+	Path string `json:"path" yaml:"path"`
+}
+
+// Find looks up the executable of the plugin name on $PATH
+func Find(name string) (string, error) {
+	return exec.LookPath(Prefix + name)
+}
+
+// List returns the plugins found on $PATH. When several executables provide
+// the same plugin, the first one on $PATH wins, like it does for Find.
+func List() []Plugin {
+	var plugins []Plugin
+	seen := map[string]bool{}
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || !strings.HasPrefix(entry.Name(), Prefix) {
+				continue
+			}
+			name := strings.TrimPrefix(entry.Name(), Prefix)
+			if runtime.GOOS == "windows" {
+				if !strings.HasSuffix(name, ".exe") {
+					continue
+				}
+				name = strings.TrimSuffix(name, ".exe")
+			} else if info, err := entry.Info(); err != nil || info.Mode()&0o111 == 0 {
+				continue
+			}
+			if name == "" || seen[name] {
+				continue
+			}
+			seen[name] = true
+			plugins = append(plugins, Plugin{Name: name, Path: filepath.Join(dir, entry.Name())})
+		}
+	}
+	return plugins
+}
+
+// Run executes the plugin at path with args, connected to the standard
+// streams of sak and with env added to the environment
+func Run(path string, args []string, env []string) error {
+	cmd := exec.Command(path, args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), env...)
+	return cmd.Run()
+}
@@ -0,0 +1,49 @@
+package release
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+type cacheEntry struct {
+	CheckedAt time.Time `json:"checked_at"`
+	Release   *Release  `json:"release"`
+}
+
+// LatestCached returns the latest release, reusing the result of a previous
+// lookup if it is younger than maxAge. A failure to read or write the cache
+// only means the release is fetched again.
+func LatestCached(ctx context.Context, maxAge time.Duration) (*Release, error) {
+	path, err := cachePath()
+	if err != nil {
+		return Latest(ctx)
+	}
+	if data, err := os.ReadFile(path); err == nil {
+		var entry cacheEntry
+		if json.Unmarshal(data, &entry) == nil && entry.Release != nil && time.Since(entry.CheckedAt) < maxAge {
+			return entry.Release, nil
+		}
+	}
+
+	r, err := Latest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if data, err := json.Marshal(cacheEntry{CheckedAt: time.Now(), Release: r}); err == nil {
+		if os.MkdirAll(filepath.Dir(path), 0o755) == nil {
+			_ = os.WriteFile(path, data, 0o644)
+		}
+	}
+	return r, nil
+}
+
+func cachePath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "sak", "latest-release.json"), nil
+}
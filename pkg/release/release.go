@@ -0,0 +1,119 @@
+package release
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/hezhizhen/sak/pkg/i18n"
+)
+
+// LatestURL is the GitHub API endpoint of the latest sak release
+const LatestURL = "https://api.github.com/repos/hezhizhen/sak/releases/latest"
+
+// ChecksumsAsset is the name of the release asset holding the sha256 sums
+// of every other asset, in sha256sum(1) format
+const ChecksumsAsset = "checksums.txt"
+
+// Release is a published sak release
+type Release struct {
+	TagName     string    `json:"tag_name"`
+	HTMLURL     string    `json:"html_url"`
+	PublishedAt time.Time `json:"published_at"`
+	Assets      []Asset   `json:"assets"`
+}
+
+// Asset is a downloadable file attached to a release
+type Asset struct {
+	Name               string `json:"name"`
+	Size               int64  `json:"size"`
+	BrowserDownloadURL string `json:"browser_download_url"`
+}
+
+// Version returns the release version without the leading "v"
+func (r *Release) Version() string {
+	return strings.TrimPrefix(r.TagName, "v")
+}
+
+// Asset looks up an asset by name
+func (r *Release) Asset(name string) (Asset, bool) {
+	for _, asset := range r.Assets {
+		if asset.Name == name {
+			return asset, true
+		}
+	}
+	return Asset{}, false
+}
+
+// AssetName returns the name of the binary asset for the running platform
+func AssetName() string {
+	name := fmt.Sprintf("sak_%s_%s", runtime.GOOS, runtime.GOARCH)
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+// Latest fetches the latest published release from GitHub
+func Latest(ctx context.Context) (*Release, error) {
+	data, err := get(ctx, LatestURL, "application/vnd.github+json")
+	if err != nil {
+		return nil, err
+	}
+	var r Release
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, i18n.Errorf("decode release: %w", err)
+	}
+	return &r, nil
+}
+
+// Download fetches the content of an asset
+func Download(ctx context.Context, asset Asset) ([]byte, error) {
+	return get(ctx, asset.BrowserDownloadURL, "application/octet-stream")
+}
+
+// VerifyChecksum checks data against the entry for name in a checksums file
+func VerifyChecksum(checksums []byte, name string, data []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 || strings.TrimPrefix(fields[1], "*") != name {
+			continue
+		}
+		sum := sha256.Sum256(data)
+		if got := hex.EncodeToString(sum[:]); !strings.EqualFold(got, fields[0]) {
+			return i18n.Errorf("checksum mismatch for %s: expected %s, got %s", name, fields[0], got)
+		}
+		return nil
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return i18n.Errorf("no checksum found for %s", name)
+}
+
+func get(ctx context.Context, url, accept string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", accept)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, i18n.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
@@ -0,0 +1,180 @@
+package snippet
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hezhizhen/sak/pkg/config"
+	"github.com/hezhizhen/sak/pkg/dryrun"
+	"github.com/hezhizhen/sak/pkg/exit"
+	"github.com/hezhizhen/sak/pkg/i18n"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Snippet is a stored piece of text, such as a shell one-liner
+type Snippet struct {
+	Name        string   `json:"name" yaml:"name"`
+	Description string   `json:"description,omitempty" yaml:"description,omitempty"`
+	Tags        []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Content     string   `json:"content" yaml:"content"`
+}
+
+// frontMatter is the optional YAML header of a snippet file
+type frontMatter struct {
+	Description string   `yaml:"description,omitempty"`
+	Tags        []string `yaml:"tags,omitempty,flow"`
+}
+
+// delimiter opens and closes the front matter
+const delimiter = "---\n"
+
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Dir returns the directory holding the snippets, one file each, so it can
+// be kept in git
+func Dir() (string, error) {
+	dir, err := config.DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "snippets"), nil
+}
+
+// path returns the file of the snippet called name
+func path(name string) (string, error) {
+	if !namePattern.MatchString(name) {
+		return "", exit.New(exit.Usage, i18n.Errorf("invalid snippet name %q (use letters, digits, '.', '_' and '-')", name))
+	}
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name), nil
+}
+
+// Load reads the snippet called name
+func Load(name string) (Snippet, error) {
+	p, err := path(name)
+	if err != nil {
+		return Snippet{}, err
+	}
+	data, err := os.ReadFile(p)
+	if errors.Is(err, os.ErrNotExist) {
+		return Snippet{}, exit.New(exit.NotFound, i18n.Errorf("unknown snippet %q", name))
+	}
+	if err != nil {
+		return Snippet{}, err
+	}
+	return parse(name, p, data)
+}
+
+// List reads all snippets ordered by name. Files that cannot be parsed are
+// skipped with a warning so that they do not hide the other snippets.
+func List() ([]Snippet, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var snippets []Snippet
+	for _, entry := range entries {
+		if entry.IsDir() || !namePattern.MatchString(entry.Name()) {
+			continue
+		}
+		s, err := Load(entry.Name())
+		if exit.Code(err) == exit.ParseError {
+			fmt.Fprintln(os.Stderr, i18n.Sprintf("warning: skipping snippet %s: %v", entry.Name(), err))
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		snippets = append(snippets, s)
+	}
+	sort.Slice(snippets, func(i, j int) bool { return snippets[i].Name < snippets[j].Name })
+	return snippets, nil
+}
+
+func parse(name, p string, data []byte) (Snippet, error) {
+	s := Snippet{Name: name, Content: string(data)}
+	if !bytes.HasPrefix(data, []byte(delimiter)) {
+		return s, nil
+	}
+	header, content, ok := bytes.Cut(data[len(delimiter):], []byte("\n"+delimiter))
+	if !ok {
+		return s, nil
+	}
+
+	var fm frontMatter
+	dec := yaml.NewDecoder(bytes.NewReader(header))
+	dec.KnownFields(true)
+	if err := dec.Decode(&fm); err != nil && !errors.Is(err, io.EOF) {
+		return Snippet{}, exit.New(exit.ParseError, i18n.Errorf("parse %s: %w", p, err))
+	}
+	s.Description = fm.Description
+	s.Tags = fm.Tags
+	s.Content = string(content)
+	return s, nil
+}
+
+// Save writes s to its file. An existing snippet of the same name is only
+// replaced if overwrite is set.
+func Save(ctx context.Context, s Snippet, overwrite bool) error {
+	p, err := path(s.Name)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(p); err == nil && !overwrite {
+		return exit.New(exit.Usage, i18n.Errorf("snippet %q already exists", s.Name))
+	}
+
+	var buf bytes.Buffer
+	// content starting with the delimiter would be read as front matter
+	// without one of its own
+	if s.Description != "" || len(s.Tags) > 0 || strings.HasPrefix(s.Content, delimiter) {
+		header, err := yaml.Marshal(frontMatter{Description: s.Description, Tags: s.Tags})
+		if err != nil {
+			return err
+		}
+		buf.WriteString(delimiter)
+		buf.Write(header)
+		buf.WriteString(delimiter)
+	}
+	buf.WriteString(s.Content)
+	if !strings.HasSuffix(s.Content, "\n") {
+		buf.WriteString("\n")
+	}
+
+	if err := dryrun.MkdirAll(ctx, filepath.Dir(p), 0o755); err != nil {
+		return err
+	}
+	return dryrun.WriteFile(ctx, p, buf.Bytes(), 0o644)
+}
+
+// Remove deletes the snippet called name
+func Remove(ctx context.Context, name string) error {
+	p, err := path(name)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(p); errors.Is(err, os.ErrNotExist) {
+		return exit.New(exit.NotFound, i18n.Errorf("unknown snippet %q", name))
+	}
+	return dryrun.Do(ctx, i18n.Sprintf("remove %s", p), func() error { return os.Remove(p) })
+}
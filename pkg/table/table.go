@@ -0,0 +1,208 @@
+package table
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/hezhizhen/sak/pkg/output"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// Align is the alignment of a column
+type Align int
+
+const (
+	// Left aligns cells to the left, the default
+	Left Align = iota
+	// Right aligns cells to the right, for numbers
+	Right
+)
+
+// Table renders rows with aligned columns, as plain text, with borders or as
+// a Markdown table depending on the output options
+type Table struct {
+	header    []string
+	rows      [][]string
+	styles    map[int]output.Role
+	align     map[int]Align
+	keyValue  bool
+	separator string
+}
+
+// New returns a table with the given column headers
+func New(header ...string) *Table {
+	return &Table{
+		header:    header,
+		styles:    map[int]output.Role{},
+		align:     map[int]Align{},
+		separator: "  ",
+	}
+}
+
+// NewKeyValue returns a headerless table of "Key: value" lines
+func NewKeyValue() *Table {
+	t := New()
+	t.keyValue = true
+	t.separator = " "
+	return t
+}
+
+// AddRow appends a row and returns its index
+func (t *Table) AddRow(cells ...string) int {
+	t.rows = append(t.rows, cells)
+	return len(t.rows) - 1
+}
+
+// SetAlign sets the alignment of a column
+func (t *Table) SetAlign(column int, align Align) {
+	t.align[column] = align
+}
+
+// SetRowStyle colors a whole row with the theme color of role when colors
+// are enabled
+func (t *Table) SetRowStyle(row int, role output.Role) {
+	t.styles[row] = role
+}
+
+// Len returns the number of rows
+func (t *Table) Len() int {
+	return len(t.rows)
+}
+
+// Render writes the table to w. The Markdown output format renders a
+// Markdown table, opts.Border draws ASCII borders, and colors are applied
+// after padding so they don't affect the alignment.
+func (t *Table) Render(w io.Writer, opts output.Options) error {
+	bw := bufio.NewWriter(w)
+	switch {
+	case opts.Format == output.Markdown:
+		t.renderMarkdown(bw)
+	case opts.Border:
+		t.renderBordered(bw, opts)
+	default:
+		t.renderPlain(bw, opts)
+	}
+	return bw.Flush()
+}
+
+// cells returns the header and rows as displayed, with the key-value colons
+// only added in plain mode
+func (t *Table) cells(plain bool) ([]string, [][]string) {
+	if !t.keyValue || !plain {
+		return t.header, t.rows
+	}
+	rows := make([][]string, len(t.rows))
+	for i, row := range t.rows {
+		rows[i] = append([]string{row[0] + ":"}, row[1:]...)
+	}
+	return t.header, rows
+}
+
+func (t *Table) widths(header []string, rows [][]string) []int {
+	var widths []int
+	for _, row := range append([][]string{header}, rows...) {
+		for i, cell := range row {
+			if i >= len(widths) {
+				widths = append(widths, 0)
+			}
+			if width := displayWidth(cell); width > widths[i] {
+				widths[i] = width
+			}
+		}
+	}
+	return widths
+}
+
+// sgr matches the ANSI color sequences cells may contain
+var sgr = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// displayWidth returns the number of terminal columns s takes, ignoring
+// color sequences
+func displayWidth(s string) int {
+	return runewidth.StringWidth(sgr.ReplaceAllString(s, ""))
+}
+
+func (t *Table) pad(cell string, column, width int) string {
+	gap := strings.Repeat(" ", width-displayWidth(cell))
+	if t.align[column] == Right {
+		return gap + cell
+	}
+	return cell + gap
+}
+
+func (t *Table) renderPlain(w *bufio.Writer, opts output.Options) {
+	header, rows := t.cells(true)
+	widths := t.widths(header, rows)
+	line := func(row []string, role output.Role) {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = t.pad(cell, i, widths[i])
+		}
+		text := strings.TrimRight(strings.Join(cells, t.separator), " ")
+		w.WriteString(opts.Style(role, text) + "\n")
+	}
+	if len(header) > 0 {
+		line(header, output.Heading)
+	}
+	for i, row := range rows {
+		line(row, t.styles[i])
+	}
+}
+
+func (t *Table) renderBordered(w *bufio.Writer, opts output.Options) {
+	header, rows := t.cells(false)
+	widths := t.widths(header, rows)
+	rule := "+"
+	for _, width := range widths {
+		rule += strings.Repeat("-", width+2) + "+"
+	}
+	line := func(row []string, role output.Role) {
+		cells := make([]string, len(widths))
+		for i := range widths {
+			cell := ""
+			if i < len(row) {
+				cell = row[i]
+			}
+			cells[i] = opts.Style(role, t.pad(cell, i, widths[i]))
+		}
+		w.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+	w.WriteString(rule + "\n")
+	if len(header) > 0 {
+		line(header, output.Heading)
+		w.WriteString(rule + "\n")
+	}
+	for i, row := range rows {
+		line(row, t.styles[i])
+	}
+	w.WriteString(rule + "\n")
+}
+
+func (t *Table) renderMarkdown(w *bufio.Writer) {
+	header, rows := t.cells(false)
+	columns := len(t.widths(header, rows))
+	line := func(row []string) {
+		cells := make([]string, columns)
+		for i := range cells {
+			if i < len(row) {
+				cells[i] = strings.ReplaceAll(sgr.ReplaceAllString(row[i], ""), "|", `\|`)
+			}
+		}
+		w.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+	line(header)
+	rule := make([]string, columns)
+	for i := range rule {
+		rule[i] = "---"
+		if t.align[i] == Right {
+			rule[i] = "--:"
+		}
+	}
+	w.WriteString("| " + strings.Join(rule, " | ") + " |\n")
+	for _, row := range rows {
+		line(row)
+	}
+}
@@ -0,0 +1,161 @@
+package timer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hezhizhen/sak/pkg/i18n"
+	"github.com/hezhizhen/sak/pkg/output"
+	"github.com/hezhizhen/sak/pkg/utils"
+)
+
+// barWidth is the number of cells of the progress bar
+const barWidth = 20
+
+// Display renders timers on a single line that is redrawn every second when
+// writing to a terminal. Elsewhere only the start and the end are printed.
+type Display struct {
+	w    io.Writer
+	live bool
+	opts output.Options
+}
+
+// NewDisplay returns a display writing to f with the colors of opts
+func NewDisplay(f *os.File, opts output.Options) *Display {
+	return &Display{w: f, live: output.IsTerminal(f), opts: opts}
+}
+
+// Countdown shows label with the time left of total until it elapses or ctx
+// is done, in which case ctx.Err() is returned
+func (d *Display) Countdown(ctx context.Context, label string, total time.Duration) error {
+	deadline := time.Now().Add(total)
+	render := func(left time.Duration) string {
+		return d.opts.Style(output.Accent, label) + "  " + Clock(left) + "  " + bar(total-left, total)
+	}
+	if !d.live {
+		fmt.Fprintf(d.w, "%s (%s)\n", label, utils.FormatDurationWith(total, utils.DurationOptions{Seconds: true}))
+	}
+
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	shown := ""
+	for {
+		left := time.Until(deadline)
+		if left <= 0 {
+			d.redraw(render(0))
+			// ring the bell so the end is noticed from another window
+			d.endLine("\a")
+			return nil
+		}
+		// round up so the display reaches 00:00 exactly when time is up
+		if line := render(left.Truncate(time.Second) + time.Second); line != shown {
+			d.redraw(line)
+			shown = line
+		}
+
+		select {
+		case <-ctx.Done():
+			d.endLine("")
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stopwatch shows the time elapsed since it started until ctx is done. A
+// value on lap completes a lap, which is printed on its own line. The lap
+// times are returned, the last one ending when ctx is done.
+func (d *Display) Stopwatch(ctx context.Context, label string, lap <-chan struct{}) []time.Duration {
+	start := time.Now()
+	lapStart := start
+	var laps []time.Duration
+	render := func(now time.Time) string {
+		line := d.opts.Style(output.Accent, label) + "  " + ClockTenths(now.Sub(start))
+		if len(laps) > 0 {
+			line += "  " + i18n.Sprintf("lap %d", len(laps)+1) + " " + ClockTenths(now.Sub(lapStart))
+		}
+		return line
+	}
+	if !d.live {
+		fmt.Fprintln(d.w, label)
+	}
+
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		now := time.Now()
+		d.redraw(render(now))
+
+		select {
+		case <-ctx.Done():
+			d.endLine("")
+			return append(laps, time.Since(lapStart))
+		case <-lap:
+			now := time.Now()
+			laps = append(laps, now.Sub(lapStart))
+			lapStart = now
+			line := i18n.Sprintf("Lap %d", len(laps)) + "  " + ClockTenths(laps[len(laps)-1]) + "  " + ClockTenths(now.Sub(start))
+			if d.live {
+				// the Enter that completed the lap moved the cursor down
+				fmt.Fprint(d.w, "\x1b[1A\r\x1b[K"+line+"\n")
+			} else {
+				fmt.Fprintln(d.w, line)
+			}
+		case <-ticker.C:
+		}
+	}
+}
+
+// redraw replaces the current line on a terminal
+func (d *Display) redraw(line string) {
+	if d.live {
+		fmt.Fprint(d.w, "\r\x1b[K"+line)
+	}
+}
+
+// endLine moves past the redrawn line on a terminal, writing suffix first
+func (d *Display) endLine(suffix string) {
+	if d.live {
+		fmt.Fprint(d.w, suffix+"\n")
+	}
+}
+
+// Clock formats d as mm:ss, or h:mm:ss from one hour on
+func Clock(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	d = d.Truncate(time.Second)
+	hours := int(d / time.Hour)
+	minutes := int(d % time.Hour / time.Minute)
+	seconds := int(d % time.Minute / time.Second)
+	if hours > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", hours, minutes, seconds)
+	}
+	return fmt.Sprintf("%02d:%02d", minutes, seconds)
+}
+
+// ClockTenths formats d like Clock with tenths of a second, e.g. 01:05.3
+func ClockTenths(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	return Clock(d) + "." + strconv.Itoa(int(d%time.Second/(100*time.Millisecond)))
+}
+
+// bar renders a progress bar for done out of total
+func bar(done, total time.Duration) string {
+	filled := barWidth
+	if total > 0 && done < total {
+		filled = int(int64(barWidth) * int64(done) / int64(total))
+	}
+	if filled < 0 {
+		filled = 0
+	}
+	return strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled)
+}
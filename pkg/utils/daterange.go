@@ -0,0 +1,105 @@
+package utils
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hezhizhen/sak/pkg/exit"
+	"github.com/hezhizhen/sak/pkg/i18n"
+)
+
+// ParseDateRange parses a date-range expression relative to now and returns
+// the half-open interval [start, end) in now's location. An expression is
+// one of
+//
+//	today, yesterday
+//	this-week, last-week, this-month, last-month, this-year, last-year
+//	past-N-days, past-N-weeks (the last N days or weeks, including today)
+//	2025, 2025-06, 2025-06-15
+//	A..B (from the start of A to the end of B, e.g. 2025-01..2025-03)
+//
+// Weeks start on Monday
+func ParseDateRange(s string, now time.Time) (start, end time.Time, err error) {
+	expr := strings.ToLower(strings.TrimSpace(s))
+	if from, to, ok := strings.Cut(expr, ".."); ok {
+		if start, _, err = parsePeriod(from, now); err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		if _, end, err = parsePeriod(to, now); err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		if !start.Before(end) {
+			return time.Time{}, time.Time{}, exit.New(exit.Usage, i18n.Errorf("date range %q ends before it starts", s))
+		}
+		return start, end, nil
+	}
+	return parsePeriod(expr, now)
+}
+
+// parsePeriod parses a single period of a date-range expression
+func parsePeriod(expr string, now time.Time) (start, end time.Time, err error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	// Monday of the current week
+	week := today.AddDate(0, 0, -(int(today.Weekday())+6)%7)
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	year := time.Date(now.Year(), 1, 1, 0, 0, 0, 0, now.Location())
+
+	switch expr {
+	case "today":
+		return today, today.AddDate(0, 0, 1), nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), today, nil
+	case "this-week":
+		return week, week.AddDate(0, 0, 7), nil
+	case "last-week":
+		return week.AddDate(0, 0, -7), week, nil
+	case "this-month":
+		return month, month.AddDate(0, 1, 0), nil
+	case "last-month":
+		return month.AddDate(0, -1, 0), month, nil
+	case "this-year":
+		return year, year.AddDate(1, 0, 0), nil
+	case "last-year":
+		return year.AddDate(-1, 0, 0), year, nil
+	}
+
+	if strings.HasPrefix(expr, "past-") {
+		fields := strings.Split(expr, "-")
+		if len(fields) == 3 {
+			n, err := strconv.Atoi(fields[1])
+			if err == nil && n > 0 {
+				switch fields[2] {
+				case "day", "days":
+					return today.AddDate(0, 0, 1-n), today.AddDate(0, 0, 1), nil
+				case "week", "weeks":
+					return today.AddDate(0, 0, 1-7*n), today.AddDate(0, 0, 1), nil
+				}
+			}
+		}
+		return time.Time{}, time.Time{}, dateRangeError(expr)
+	}
+
+	for _, layout := range []struct {
+		format string
+		years  int
+		months int
+		days   int
+	}{
+		{"2006-01-02", 0, 0, 1},
+		{"2006-01", 0, 1, 0},
+		{"2006", 1, 0, 0},
+	} {
+		if len(expr) != len(layout.format) {
+			continue
+		}
+		if t, err := time.ParseInLocation(layout.format, expr, now.Location()); err == nil {
+			return t, t.AddDate(layout.years, layout.months, layout.days), nil
+		}
+	}
+	return time.Time{}, time.Time{}, dateRangeError(expr)
+}
+
+func dateRangeError(s string) error {
+	return exit.New(exit.Usage, i18n.Errorf("invalid date range %q (expected e.g. today, last-week, 2025-06, 2025-01..2025-03 or past-30-days)", s))
+}
@@ -0,0 +1,97 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hezhizhen/sak/pkg/exit"
+)
+
+func TestParseDateRange(t *testing.T) {
+	loc := time.FixedZone("UTC+8", 8*60*60)
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, loc)
+	}
+	// a Wednesday
+	wednesday := time.Date(2025, 7, 16, 15, 4, 5, 0, loc)
+	sunday := time.Date(2025, 7, 20, 23, 59, 0, 0, loc)
+	monday := time.Date(2025, 7, 14, 0, 0, 0, 0, loc)
+	newYear := time.Date(2025, 1, 1, 8, 0, 0, 0, loc)
+
+	tests := []struct {
+		name       string
+		input      string
+		now        time.Time
+		start, end time.Time
+	}{
+		{"today", "today", wednesday, date(2025, 7, 16), date(2025, 7, 17)},
+		{"case and spaces", "  Today ", wednesday, date(2025, 7, 16), date(2025, 7, 17)},
+		{"yesterday", "yesterday", wednesday, date(2025, 7, 15), date(2025, 7, 16)},
+		{"yesterday across a year", "yesterday", newYear, date(2024, 12, 31), date(2025, 1, 1)},
+		{"this week", "this-week", wednesday, date(2025, 7, 14), date(2025, 7, 21)},
+		{"this week on sunday", "this-week", sunday, date(2025, 7, 14), date(2025, 7, 21)},
+		{"this week on monday", "this-week", monday, date(2025, 7, 14), date(2025, 7, 21)},
+		{"last week", "last-week", wednesday, date(2025, 7, 7), date(2025, 7, 14)},
+		{"this month", "this-month", wednesday, date(2025, 7, 1), date(2025, 8, 1)},
+		{"last month", "last-month", wednesday, date(2025, 6, 1), date(2025, 7, 1)},
+		{"last month across a year", "last-month", newYear, date(2024, 12, 1), date(2025, 1, 1)},
+		{"this year", "this-year", wednesday, date(2025, 1, 1), date(2026, 1, 1)},
+		{"last year", "last-year", wednesday, date(2024, 1, 1), date(2025, 1, 1)},
+		{"past day", "past-1-day", wednesday, date(2025, 7, 16), date(2025, 7, 17)},
+		{"past days", "past-7-days", wednesday, date(2025, 7, 10), date(2025, 7, 17)},
+		{"past weeks", "past-2-weeks", wednesday, date(2025, 7, 3), date(2025, 7, 17)},
+		{"year", "2024", wednesday, date(2024, 1, 1), date(2025, 1, 1)},
+		{"month", "2024-02", wednesday, date(2024, 2, 1), date(2024, 3, 1)},
+		{"day", "2024-02-29", wednesday, date(2024, 2, 29), date(2024, 3, 1)},
+		{"range of months", "2025-01..2025-03", wednesday, date(2025, 1, 1), date(2025, 4, 1)},
+		{"range of one day", "2025-03-01..2025-03-01", wednesday, date(2025, 3, 1), date(2025, 3, 2)},
+		{"range of named periods", "last-week..today", wednesday, date(2025, 7, 7), date(2025, 7, 17)},
+		{"range of mixed periods", "2024..this-month", wednesday, date(2024, 1, 1), date(2025, 8, 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, err := ParseDateRange(tt.input, tt.now)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !start.Equal(tt.start) || !end.Equal(tt.end) {
+				t.Errorf("got [%v, %v), want [%v, %v)", start, end, tt.start, tt.end)
+			}
+			if start.Location() != loc || end.Location() != loc {
+				t.Errorf("got locations %v and %v, want %v", start.Location(), end.Location(), loc)
+			}
+		})
+	}
+}
+
+func TestParseDateRangeErrors(t *testing.T) {
+	now := time.Date(2025, 7, 16, 15, 4, 5, 0, time.UTC)
+	for _, input := range []string{
+		"",
+		"tomorrow",
+		"past-0-days",
+		"past--1-days",
+		"past-x-days",
+		"past-3-months",
+		"past-days",
+		"2025-13",
+		"2025-02-30",
+		"2025-1",
+		"25",
+		"2025-03..2025-02",
+		"today..yesterday",
+		"..today",
+		"today..",
+		"2025..2026..2027",
+	} {
+		t.Run(input, func(t *testing.T) {
+			_, _, err := ParseDateRange(input, now)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if code := exit.Code(err); code != exit.Usage {
+				t.Errorf("got exit code %d (%v), want %d", code, err, exit.Usage)
+			}
+		})
+	}
+}
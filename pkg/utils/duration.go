@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DurationOptions controls how FormatDurationWith renders a duration
+type DurationOptions struct {
+	// Seconds includes the seconds, which are dropped otherwise
+	Seconds bool
+	// Compact leaves out the spaces between units: "9h38m"
+	Compact bool
+	// Signed prefixes positive durations with "+", for deltas
+	Signed bool
+	// Pad zero-pads minutes and seconds following a larger unit: "9h 05m"
+	Pad bool
+	// Decimal renders the duration as decimal hours: "9.6h" with 1 decimal
+	Decimal bool
+	// Decimals is the number of decimals in decimal mode
+	Decimals int
+}
+
+// FormatDuration renders d in hours and minutes, e.g. "9h 38m"
+func FormatDuration(d time.Duration) string {
+	return FormatDurationWith(d, DurationOptions{})
+}
+
+// FormatDurationWith renders d according to opts. The sign is that of the
+// rounded duration, so a duration that rounds to zero has none.
+func FormatDurationWith(d time.Duration, opts DurationOptions) string {
+	if opts.Decimal {
+		scale := math.Pow10(opts.Decimals)
+		hours := math.Round(d.Hours()*scale) / scale
+		return durationSign(hours < 0, hours > 0, opts) + strconv.FormatFloat(math.Abs(hours), 'f', opts.Decimals, 64) + "h"
+	}
+
+	if opts.Seconds {
+		d = d.Truncate(time.Second)
+	} else {
+		d = d.Round(time.Minute)
+	}
+	sign := durationSign(d < 0, d > 0, opts)
+	if d < 0 {
+		d = -d
+	}
+	hours := int64(d / time.Hour)
+	minutes := int64(d % time.Hour / time.Minute)
+	seconds := int64(d % time.Minute / time.Second)
+
+	var parts []string
+	if hours > 0 {
+		parts = append(parts, fmt.Sprintf("%dh", hours))
+	}
+	if hours > 0 || minutes > 0 || !opts.Seconds {
+		parts = append(parts, formatUnit(minutes, "m", opts.Pad && len(parts) > 0))
+	}
+	if opts.Seconds {
+		parts = append(parts, formatUnit(seconds, "s", opts.Pad && len(parts) > 0))
+	}
+
+	separator := " "
+	if opts.Compact {
+		separator = ""
+	}
+	return sign + strings.Join(parts, separator)
+}
+
+func durationSign(negative, positive bool, opts DurationOptions) string {
+	switch {
+	case negative:
+		return "-"
+	case positive && opts.Signed:
+		return "+"
+	}
+	return ""
+}
+
+func formatUnit(value int64, unit string, pad bool) string {
+	if pad {
+		return fmt.Sprintf("%02d%s", value, unit)
+	}
+	return fmt.Sprintf("%d%s", value, unit)
+}
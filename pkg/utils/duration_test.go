@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatDurationWith(t *testing.T) {
+	d := 9*time.Hour + 38*time.Minute
+	tests := []struct {
+		name string
+		d    time.Duration
+		opts DurationOptions
+		want string
+	}{
+		{"default", d, DurationOptions{}, "9h 38m"},
+		{"zero", 0, DurationOptions{}, "0m"},
+		{"rounded to the minute", 9*time.Hour + 37*time.Minute + 30*time.Second, DurationOptions{}, "9h 38m"},
+		{"seconds", d + 5*time.Second, DurationOptions{Seconds: true}, "9h 38m 5s"},
+		{"seconds only", 42 * time.Second, DurationOptions{Seconds: true}, "42s"},
+		{"compact", d, DurationOptions{Compact: true}, "9h38m"},
+		{"padded", 9*time.Hour + 5*time.Minute + 3*time.Second, DurationOptions{Seconds: true, Pad: true}, "9h 05m 03s"},
+		{"padding needs a larger unit", 5 * time.Minute, DurationOptions{Pad: true}, "5m"},
+		{"signed", d, DurationOptions{Signed: true}, "+9h 38m"},
+		{"negative", -d, DurationOptions{}, "-9h 38m"},
+		{"negative rounding to zero", -20 * time.Second, DurationOptions{}, "0m"},
+		{"positive rounding to zero", 20 * time.Second, DurationOptions{Signed: true}, "0m"},
+		{"negative rounding away from zero", -40 * time.Second, DurationOptions{}, "-1m"},
+		{"negative below a second", -500 * time.Millisecond, DurationOptions{Seconds: true}, "0s"},
+		{"decimal", d, DurationOptions{Decimal: true, Decimals: 1}, "9.6h"},
+		{"decimal without decimals", d, DurationOptions{Decimal: true}, "10h"},
+		{"decimal with two decimals", d, DurationOptions{Decimal: true, Decimals: 2}, "9.63h"},
+		{"decimal negative", -d, DurationOptions{Decimal: true, Decimals: 1, Signed: true}, "-9.6h"},
+		{"decimal rounding to zero", -time.Minute, DurationOptions{Decimal: true, Decimals: 1, Signed: true}, "0.0h"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatDurationWith(tt.d, tt.opts); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
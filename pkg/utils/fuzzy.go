@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// FuzzyScore reports whether the characters of pattern occur in text in
+// order, ignoring case, and scores the match. Consecutive characters and
+// characters starting a word score higher, so "gc" ranks "git commit"
+// above "logic".
+func FuzzyScore(pattern, text string) (int, bool) {
+	p := []rune(strings.ToLower(pattern))
+	t := []rune(strings.ToLower(text))
+	if len(p) == 0 {
+		return 0, true
+	}
+
+	score, pi, previous := 0, 0, -2
+	for ti := 0; ti < len(t) && pi < len(p); ti++ {
+		if t[ti] != p[pi] {
+			continue
+		}
+		score++
+		if ti == previous+1 {
+			score += 2
+		}
+		if ti == 0 || !unicode.IsLetter(t[ti-1]) && !unicode.IsDigit(t[ti-1]) {
+			score += 3
+		}
+		previous = ti
+		pi++
+	}
+	return score, pi == len(p)
+}
@@ -0,0 +1,59 @@
+package utils
+
+import "testing"
+
+func TestFuzzyScore(t *testing.T) {
+	tests := []struct {
+		pattern, text string
+		score         int
+		ok            bool
+	}{
+		{"", "anything", 0, true},
+		{"", "", 0, true},
+		{"a", "", 0, false},
+		{"abc", "abc", 10, true},
+		{"ABC", "abc", 10, true},
+		{"abc", "ABC", 10, true},
+		{"ac", "abc", 5, true},
+		{"ba", "abc", 0, false},
+		{"abcd", "abc", 10, false},
+		{"gc", "git commit", 8, true},
+		{"gc", "logic", 2, true},
+		{"co", "go vet config", 7, true},
+		{"c", "x-c", 4, true},
+		{"2", "v2", 1, true},
+		{"é", "Café", 1, true},
+		{"日本", "日本語", 7, true},
+	}
+	for _, tt := range tests {
+		score, ok := FuzzyScore(tt.pattern, tt.text)
+		if ok != tt.ok || (ok && score != tt.score) {
+			t.Errorf("FuzzyScore(%q, %q) = %d, %v, want %d, %v", tt.pattern, tt.text, score, ok, tt.score, tt.ok)
+		}
+	}
+}
+
+func TestFuzzyScoreRanking(t *testing.T) {
+	tests := []struct {
+		pattern       string
+		better, worse string
+	}{
+		// characters starting a word
+		{"gc", "git commit", "logic"},
+		// consecutive characters
+		{"co", "config", "clock"},
+	}
+	for _, tt := range tests {
+		better, ok := FuzzyScore(tt.pattern, tt.better)
+		if !ok {
+			t.Fatalf("FuzzyScore(%q, %q) did not match", tt.pattern, tt.better)
+		}
+		worse, ok := FuzzyScore(tt.pattern, tt.worse)
+		if !ok {
+			t.Fatalf("FuzzyScore(%q, %q) did not match", tt.pattern, tt.worse)
+		}
+		if better <= worse {
+			t.Errorf("%q scores %d on %q and %d on %q, want the first higher", tt.pattern, better, tt.better, worse, tt.worse)
+		}
+	}
+}
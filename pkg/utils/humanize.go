@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"time"
+
+	"github.com/hezhizhen/sak/pkg/i18n"
+)
+
+type humanUnit struct {
+	size time.Duration
+	// past and future formats, singular then plural
+	past   [2]string
+	future [2]string
+}
+
+// humanUnits are ordered from the largest; a unit is used once the duration
+// is at least one of it
+var humanUnits = []humanUnit{
+	{365 * 24 * time.Hour, [2]string{"%d year ago", "%d years ago"}, [2]string{"in %d year", "in %d years"}},
+	{30 * 24 * time.Hour, [2]string{"%d month ago", "%d months ago"}, [2]string{"in %d month", "in %d months"}},
+	{7 * 24 * time.Hour, [2]string{"%d week ago", "%d weeks ago"}, [2]string{"in %d week", "in %d weeks"}},
+	{24 * time.Hour, [2]string{"%d day ago", "%d days ago"}, [2]string{"in %d day", "in %d days"}},
+	{time.Hour, [2]string{"%d hour ago", "%d hours ago"}, [2]string{"in %d hour", "in %d hours"}},
+	{time.Minute, [2]string{"%d minute ago", "%d minutes ago"}, [2]string{"in %d minute", "in %d minutes"}},
+}
+
+// HumanizeSince describes t relative to now in the selected language, e.g.
+// "3 days ago" or "in 2 weeks"
+func HumanizeSince(t time.Time) string {
+	return HumanizeDelta(time.Since(t))
+}
+
+// HumanizeDelta describes a point in time d before now (or after now if d is
+// negative) in the selected language
+func HumanizeDelta(d time.Duration) string {
+	future := d < 0
+	if future {
+		d = -d
+	}
+	for _, unit := range humanUnits {
+		if d < unit.size {
+			continue
+		}
+		n := int(d / unit.size)
+		plural := 0
+		if n != 1 {
+			plural = 1
+		}
+		if future {
+			return i18n.Sprintf(unit.future[plural], n)
+		}
+		return i18n.Sprintf(unit.past[plural], n)
+	}
+	return i18n.T("just now")
+}
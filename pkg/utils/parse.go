@@ -0,0 +1,190 @@
+package utils
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hezhizhen/sak/pkg/exit"
+	"github.com/hezhizhen/sak/pkg/i18n"
+)
+
+// ParseDuration parses a duration written the way people type it: Go
+// notation ("9h30m", "9h 30m", "45m"), decimal hours ("9.5h") or a clock
+// style h:mm[:ss] ("9:30"). A leading "+" or "-" is allowed
+func ParseDuration(s string) (time.Duration, error) {
+	text := strings.ToLower(strings.Join(strings.Fields(s), ""))
+	sign := time.Duration(1)
+	switch {
+	case strings.HasPrefix(text, "-"):
+		sign, text = -1, text[1:]
+	case strings.HasPrefix(text, "+"):
+		text = text[1:]
+	}
+	// time.ParseDuration would take a second sign
+	if text == "" || text[0] == '-' || text[0] == '+' {
+		return 0, durationError(s)
+	}
+
+	if strings.Contains(text, ":") {
+		hours, minutes, seconds, ok := splitClock(text)
+		if !ok {
+			return 0, durationError(s)
+		}
+		if minutes >= 60 || seconds >= 60 {
+			return 0, exit.New(exit.Usage, i18n.Errorf("minutes and seconds must be below 60 in %q", s))
+		}
+		d := time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+		return sign * d, nil
+	}
+
+	if _, err := strconv.ParseFloat(text, 64); err == nil {
+		return 0, exit.New(exit.Usage, i18n.Errorf("duration %q has no unit (did you mean %sh or %sm?)", s, text, text))
+	}
+	d, err := time.ParseDuration(text)
+	if err != nil {
+		return 0, durationError(s)
+	}
+	return sign * d, nil
+}
+
+// ParseClock parses a time of day, either 24-hour ("19:05", "7:05:30") or
+// 12-hour ("7pm", "7:30 am"), and returns it as the offset from midnight
+func ParseClock(s string) (time.Duration, error) {
+	text := strings.ToLower(strings.Join(strings.Fields(s), ""))
+	meridiem := ""
+	if strings.HasSuffix(text, "am") || strings.HasSuffix(text, "pm") {
+		meridiem, text = text[len(text)-2:], text[:len(text)-2]
+	}
+	if text == "" {
+		return 0, clockError(s)
+	}
+
+	var hours, minutes, seconds int
+	if strings.Contains(text, ":") {
+		var ok bool
+		if hours, minutes, seconds, ok = splitClock(text); !ok {
+			return 0, clockError(s)
+		}
+	} else {
+		// a bare hour only makes sense with am/pm
+		n, err := strconv.Atoi(text)
+		if err != nil || meridiem == "" {
+			return 0, clockError(s)
+		}
+		hours = n
+	}
+
+	if meridiem != "" {
+		if hours < 1 || hours > 12 {
+			return 0, exit.New(exit.Usage, i18n.Errorf("hour must be between 1 and 12 in %q", s))
+		}
+		hours %= 12
+		if meridiem == "pm" {
+			hours += 12
+		}
+	} else if hours > 23 {
+		return 0, exit.New(exit.Usage, i18n.Errorf("hour must be between 0 and 23 in %q", s))
+	}
+	if minutes >= 60 || seconds >= 60 {
+		return 0, exit.New(exit.Usage, i18n.Errorf("minutes and seconds must be below 60 in %q", s))
+	}
+	return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second, nil
+}
+
+// timeLayouts are the layouts ParseTime accepts besides Unix timestamps
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	time.RFC1123Z,
+	time.RFC1123,
+	time.UnixDate,
+}
+
+// timestampUnits returns the number of units per second of a Unix timestamp
+// of magnitude abs, which is told to be in seconds, milliseconds,
+// microseconds or nanoseconds by its size
+func timestampUnits(abs float64) float64 {
+	switch {
+	case abs >= 1e17:
+		return 1e9
+	case abs >= 1e14:
+		return 1e6
+	case abs >= 1e11:
+		return 1e3
+	}
+	return 1
+}
+
+// ParseTime parses a point in time: "now", a Unix timestamp in seconds,
+// milliseconds, microseconds or nanoseconds (told apart by their size), or
+// a date such as "2025-07-16 09:00" or RFC 3339. Times without a zone are
+// taken in loc.
+func ParseTime(s string, loc *time.Location, now time.Time) (time.Time, error) {
+	text := strings.TrimSpace(s)
+	if strings.EqualFold(text, "now") {
+		return now.In(loc), nil
+	}
+
+	if n, err := strconv.ParseInt(text, 10, 64); err == nil {
+		switch timestampUnits(math.Abs(float64(n))) {
+		case 1e9:
+			return time.Unix(0, n).In(loc), nil
+		case 1e6:
+			return time.UnixMicro(n).In(loc), nil
+		case 1e3:
+			return time.UnixMilli(n).In(loc), nil
+		default:
+			return time.Unix(n, 0).In(loc), nil
+		}
+	}
+	if f, err := strconv.ParseFloat(text, 64); (err == nil || errors.Is(err, strconv.ErrRange)) && !math.IsNaN(f) {
+		if err != nil || math.IsInf(f, 0) || math.Abs(f) >= math.MaxInt64 {
+			return time.Time{}, exit.New(exit.Usage, i18n.Errorf("timestamp %q is out of range", s))
+		}
+		sec, frac := math.Modf(f / timestampUnits(math.Abs(f)))
+		return time.Unix(int64(sec), int64(frac*1e9)).In(loc), nil
+	}
+
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, text, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, exit.New(exit.Usage, i18n.Errorf("invalid time %q (expected e.g. 1721102400, 2025-07-16 09:00 or 2025-07-16T09:00:00Z)", s))
+}
+
+// splitClock splits "h:mm" or "h:mm:ss"; minutes and seconds take two digits
+func splitClock(s string) (hours, minutes, seconds int, ok bool) {
+	parts := strings.Split(s, ":")
+	if len(parts) > 3 {
+		return 0, 0, 0, false
+	}
+	values := make([]int, 3)
+	for i, part := range parts {
+		if part == "" || (i > 0 && len(part) != 2) {
+			return 0, 0, 0, false
+		}
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return 0, 0, 0, false
+		}
+		values[i] = n
+	}
+	return values[0], values[1], values[2], true
+}
+
+func durationError(s string) error {
+	return exit.New(exit.Usage, i18n.Errorf("invalid duration %q (expected e.g. 9h30m, 9:30 or 9.5h)", s))
+}
+
+func clockError(s string) error {
+	return exit.New(exit.Usage, i18n.Errorf("invalid time of day %q (expected e.g. 19:05 or 7pm)", s))
+}
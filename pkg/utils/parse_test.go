@@ -0,0 +1,214 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hezhizhen/sak/pkg/exit"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		input string
+		want  time.Duration
+	}{
+		{"9h30m", 9*time.Hour + 30*time.Minute},
+		{"9h 30m", 9*time.Hour + 30*time.Minute},
+		{"45m", 45 * time.Minute},
+		{"9.5h", 9*time.Hour + 30*time.Minute},
+		{"9H30M", 9*time.Hour + 30*time.Minute},
+		{"9:30", 9*time.Hour + 30*time.Minute},
+		{"0:05:30", 5*time.Minute + 30*time.Second},
+		{"100:00", 100 * time.Hour},
+		{"-1h", -time.Hour},
+		{"- 9:30", -(9*time.Hour + 30*time.Minute)},
+		{"+45m", 45 * time.Minute},
+		{"0s", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseDuration(tt.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDurationErrors(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"-",
+		"+",
+		"9",
+		"1.5",
+		"9:60",
+		"9:30:60",
+		"9:5",
+		"9:",
+		":30",
+		"1:00:00:00",
+		"9:-1",
+		"--1h",
+		"-+1h",
+		"9 hours",
+	} {
+		t.Run(input, func(t *testing.T) {
+			_, err := ParseDuration(input)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if code := exit.Code(err); code != exit.Usage {
+				t.Errorf("got exit code %d (%v), want %d", code, err, exit.Usage)
+			}
+		})
+	}
+}
+
+func TestParseClock(t *testing.T) {
+	tests := []struct {
+		input string
+		want  time.Duration
+	}{
+		{"19:05", 19*time.Hour + 5*time.Minute},
+		{"7:05:30", 7*time.Hour + 5*time.Minute + 30*time.Second},
+		{"0:00", 0},
+		{"23:59:59", 23*time.Hour + 59*time.Minute + 59*time.Second},
+		{"7pm", 19 * time.Hour},
+		{"7 PM", 19 * time.Hour},
+		{"7:30 am", 7*time.Hour + 30*time.Minute},
+		{"12am", 0},
+		{"12:15am", 15 * time.Minute},
+		{"12pm", 12 * time.Hour},
+		{"12:45 pm", 12*time.Hour + 45*time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseClock(tt.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseClockErrors(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"pm",
+		"7",
+		"24:00",
+		"13pm",
+		"0am",
+		"0:30 am",
+		"7:60",
+		"7:00:60",
+		"7:5",
+		"-1:00",
+		"seven",
+	} {
+		t.Run(input, func(t *testing.T) {
+			_, err := ParseClock(input)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if code := exit.Code(err); code != exit.Usage {
+				t.Errorf("got exit code %d (%v), want %d", code, err, exit.Usage)
+			}
+		})
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	now := time.Date(2025, 7, 16, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{"now", now},
+		{"NOW", now},
+		{"1721102400", time.Unix(1721102400, 0)},
+		{"0", time.Unix(0, 0)},
+		{"-1", time.Unix(-1, 0)},
+		{"99999999999", time.Unix(99999999999, 0)},
+		{"1721102400123", time.UnixMilli(1721102400123)},
+		{"-1721102400123", time.UnixMilli(-1721102400123)},
+		{"1721102400123456", time.UnixMicro(1721102400123456)},
+		{"1721102400123456789", time.Unix(0, 1721102400123456789)},
+		{"1721102400.5", time.Unix(1721102400, 500_000_000)},
+		{"1721102400123.5", time.Unix(1721102400, 123_500_000)},
+		{"1721102400123456.5", time.Unix(1721102400, 123_456_500)},
+		{"1.7211024e18", time.Unix(1721102400, 0)},
+		{"-0.5", time.Unix(0, -500_000_000)},
+		{"2025-07-16", time.Date(2025, 7, 16, 0, 0, 0, 0, loc)},
+		{"2025-07-16 09:00", time.Date(2025, 7, 16, 9, 0, 0, 0, loc)},
+		{"2025-07-16T09:00:30", time.Date(2025, 7, 16, 9, 0, 30, 0, loc)},
+		{"2025-07-16T09:00:00Z", time.Date(2025, 7, 16, 9, 0, 0, 0, time.UTC)},
+		{"2025-07-16 09:00:00+08:00", time.Date(2025, 7, 16, 1, 0, 0, 0, time.UTC)},
+		{"Wed, 16 Jul 2025 09:00:00 +0000", time.Date(2025, 7, 16, 9, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseTime(tt.input, loc, now)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			// fractional timestamps go through float64, which is exact to
+			// well below a microsecond for the values above
+			if diff := got.Sub(tt.want); diff < -time.Microsecond || diff > time.Microsecond {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTimeLocation(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	now := time.Date(2025, 7, 16, 9, 0, 0, 0, time.UTC)
+	for _, input := range []string{"now", "1721102400", "1721102400.5", "2025-07-16 09:00"} {
+		got, err := ParseTime(input, loc, now)
+		if err != nil {
+			t.Fatalf("ParseTime(%q): unexpected error: %v", input, err)
+		}
+		if got.Location() != loc {
+			t.Errorf("ParseTime(%q) is in %v, want %v", input, got.Location(), loc)
+		}
+	}
+}
+
+func TestParseTimeErrors(t *testing.T) {
+	now := time.Date(2025, 7, 16, 9, 0, 0, 0, time.UTC)
+	for _, input := range []string{
+		"",
+		"inf",
+		"-Inf",
+		"+infinity",
+		"nan",
+		"NaN",
+		"1e30",
+		"-1e30",
+		"1e400",
+		"9223372036854775808",
+		"-9223372036854775809",
+		"tomorrow",
+		"2025-13-01",
+		"2025-07-16 25:00",
+	} {
+		t.Run(input, func(t *testing.T) {
+			_, err := ParseTime(input, time.UTC, now)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if code := exit.Code(err); code != exit.Usage {
+				t.Errorf("got exit code %d (%v), want %d", code, err, exit.Usage)
+			}
+		})
+	}
+}
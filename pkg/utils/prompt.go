@@ -0,0 +1,169 @@
+package utils
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/hezhizhen/sak/pkg/i18n"
+	"github.com/hezhizhen/sak/pkg/output"
+)
+
+// PromptOptions controls how Confirm, Select and Input behave
+type PromptOptions struct {
+	// Yes answers every confirmation with yes without asking
+	Yes bool
+	// Quiet never asks and takes the default answers
+	Quiet bool
+	// In and Out are where answers are read from and questions written to,
+	// os.Stdin and os.Stderr if nil
+	In  io.Reader
+	Out io.Writer
+}
+
+type promptKey struct{}
+
+type prompter struct {
+	opts PromptOptions
+	in   *bufio.Reader
+}
+
+var stdinPrompter = &prompter{}
+
+// WithPromptOptions returns a context in which the prompt helpers use opts
+func WithPromptOptions(ctx context.Context, opts PromptOptions) context.Context {
+	return context.WithValue(ctx, promptKey{}, &prompter{opts: opts})
+}
+
+func prompterFrom(ctx context.Context) *prompter {
+	p, ok := ctx.Value(promptKey{}).(*prompter)
+	if !ok {
+		p = stdinPrompter
+	}
+	if p.in == nil {
+		in := p.opts.In
+		if in == nil {
+			in = os.Stdin
+		}
+		// shared so consecutive prompts don't lose buffered input
+		p.in = bufio.NewReader(in)
+	}
+	return p
+}
+
+// interactive reports whether questions can be asked: not in quiet mode and
+// reading from a terminal (or from a reader that is not a file, in tests)
+func (p *prompter) interactive() bool {
+	if p.opts.Quiet {
+		return false
+	}
+	switch in := p.opts.In.(type) {
+	case nil:
+		return output.IsTerminal(os.Stdin)
+	case *os.File:
+		return output.IsTerminal(in)
+	}
+	return true
+}
+
+func (p *prompter) out() io.Writer {
+	if p.opts.Out != nil {
+		return p.opts.Out
+	}
+	return os.Stderr
+}
+
+// ask writes question and reads one trimmed line; ok is false at the end of
+// the input
+func (p *prompter) ask(question string) (answer string, ok bool, err error) {
+	fmt.Fprint(p.out(), question)
+	line, err := p.in.ReadString('\n')
+	if err == io.EOF {
+		fmt.Fprintln(p.out())
+		return strings.TrimSpace(line), line != "", nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return strings.TrimSpace(line), true, nil
+}
+
+// Confirm asks a yes/no question. It returns true without asking when --yes
+// is given, and def when it can't ask or the answer is empty.
+func Confirm(ctx context.Context, question string, def bool) (bool, error) {
+	p := prompterFrom(ctx)
+	if p.opts.Yes {
+		return true, nil
+	}
+	if !p.interactive() {
+		return def, nil
+	}
+
+	hint := "(y/N)"
+	if def {
+		hint = "(Y/n)"
+	}
+	for {
+		answer, ok, err := p.ask(question + " " + hint + " ")
+		if err != nil || !ok || answer == "" {
+			return def, err
+		}
+		switch strings.ToLower(answer) {
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+		fmt.Fprintln(p.out(), i18n.T("Please answer yes or no."))
+	}
+}
+
+// Select asks to choose one of options by number and returns its index. It
+// returns def without asking when it can't ask or the answer is empty.
+func Select(ctx context.Context, question string, options []string, def int) (int, error) {
+	p := prompterFrom(ctx)
+	if !p.interactive() {
+		return def, nil
+	}
+
+	fmt.Fprintln(p.out(), question)
+	for i, option := range options {
+		marker := " "
+		if i == def {
+			marker = "*"
+		}
+		fmt.Fprintf(p.out(), "%s %d) %s\n", marker, i+1, option)
+	}
+	for {
+		answer, ok, err := p.ask(i18n.Sprintf("Choose 1-%d [%d]: ", len(options), def+1))
+		if err != nil || !ok || answer == "" {
+			return def, err
+		}
+		if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(options) {
+			return n - 1, nil
+		}
+		fmt.Fprintln(p.out(), i18n.Sprintf("Please enter a number between 1 and %d.", len(options)))
+	}
+}
+
+// Input asks for a line of text. It returns def without asking when it can't
+// ask or the answer is empty.
+func Input(ctx context.Context, question string, def string) (string, error) {
+	p := prompterFrom(ctx)
+	if !p.interactive() {
+		return def, nil
+	}
+
+	if def != "" {
+		question += " [" + def + "]"
+	}
+	answer, ok, err := p.ask(question + ": ")
+	if err != nil || !ok || answer == "" {
+		return def, err
+	}
+	return answer, nil
+}
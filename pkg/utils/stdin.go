@@ -0,0 +1,29 @@
+//go:build !windows
+
+package utils
+
+import (
+	"io"
+	"os"
+
+	"github.com/hezhizhen/sak/pkg/output"
+)
+
+// OpenStdin returns a reader of stdin whose Close interrupts a pending Read,
+// so that a goroutine reading it stops with its command instead of taking
+// the input meant for what runs next, such as the next line of sak shell.
+// A terminal is opened again as /dev/tty, whose own file description is
+// read through the poller without changing the mode of stdin, which the
+// terminal shares with stdout and the parent shell. Other input is
+// returned as is, and Close does not interrupt it.
+func OpenStdin() (io.ReadCloser, error) {
+	if !output.IsTerminal(os.Stdin) {
+		return io.NopCloser(os.Stdin), nil
+	}
+	tty, err := os.Open("/dev/tty")
+	if err != nil {
+		// no controlling terminal
+		return io.NopCloser(os.Stdin), nil
+	}
+	return tty, nil
+}
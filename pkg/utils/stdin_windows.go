@@ -0,0 +1,12 @@
+package utils
+
+import (
+	"io"
+	"os"
+)
+
+// OpenStdin returns stdin. Unlike on other systems, Close does not
+// interrupt a pending Read.
+func OpenStdin() (io.ReadCloser, error) {
+	return io.NopCloser(os.Stdin), nil
+}
@@ -0,0 +1,111 @@
+package version
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Compare compares two semver strings, returning -1, 0 or 1 when a is
+// older than, equal to or newer than b. A leading "v" and any build
+// metadata are ignored, and a pre-release sorts before its release.
+func Compare(a, b string) int {
+	aCore, aPre := split(a)
+	bCore, bPre := split(b)
+	for i := 0; i < len(aCore) || i < len(bCore); i++ {
+		var x, y int
+		if i < len(aCore) {
+			x = aCore[i]
+		}
+		if i < len(bCore) {
+			y = bCore[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case aPre == bPre:
+		return 0
+	case aPre == "":
+		return 1
+	case bPre == "":
+		return -1
+	}
+	return comparePrerelease(aPre, bPre)
+}
+
+// comparePrerelease compares two pre-release versions such as rc.9 and
+// rc.10 the way semver does: identifier by identifier, numerically if both
+// are numbers, numbers before words, and a prefix before longer versions
+func comparePrerelease(a, b string) int {
+	aIDs := strings.Split(a, ".")
+	bIDs := strings.Split(b, ".")
+	for i := 0; i < len(aIDs) && i < len(bIDs); i++ {
+		x, y := aIDs[i], bIDs[i]
+		if x == y {
+			continue
+		}
+		xNum, yNum := isNumeric(x), isNumeric(y)
+		switch {
+		case xNum && yNum:
+			// without leading zeros, a longer number is a larger one
+			x, y = strings.TrimLeft(x, "0"), strings.TrimLeft(y, "0")
+			if len(x) != len(y) {
+				return compareInts(len(x), len(y))
+			}
+		case xNum:
+			return -1
+		case yNum:
+			return 1
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return compareInts(len(aIDs), len(bIDs))
+}
+
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func compareInts(x, y int) int {
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
+
+func split(v string) ([]int, string) {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.IndexByte(v, '+'); i >= 0 {
+		v = v[:i]
+	}
+	pre := ""
+	if i := strings.IndexByte(v, '-'); i >= 0 {
+		v, pre = v[:i], v[i+1:]
+	}
+	var core []int
+	for _, part := range strings.Split(v, ".") {
+		n, _ := strconv.Atoi(part)
+		core = append(core, n)
+	}
+	return core, pre
+}
@@ -0,0 +1,134 @@
+package weather
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	"github.com/hezhizhen/sak/pkg/exit"
+	"github.com/hezhizhen/sak/pkg/i18n"
+)
+
+// openMeteo queries https://open-meteo.com, which is free for
+// non-commercial use. With a key, the customer API is used instead.
+type openMeteo struct {
+	key string
+}
+
+func (p *openMeteo) Fetch(ctx context.Context, city string, days int) (*Report, error) {
+	geocoding, forecast := "https://geocoding-api.open-meteo.com/v1/search", "https://api.open-meteo.com/v1/forecast"
+	if p.key != "" {
+		geocoding, forecast = "https://customer-geocoding-api.open-meteo.com/v1/search", "https://customer-api.open-meteo.com/v1/forecast"
+	}
+	query := func(v url.Values) url.Values {
+		if p.key != "" {
+			v.Set("apikey", p.key)
+		}
+		return v
+	}
+
+	var places struct {
+		Results []struct {
+			Name      string  `json:"name"`
+			Admin1    string  `json:"admin1"`
+			Country   string  `json:"country"`
+			Latitude  float64 `json:"latitude"`
+			Longitude float64 `json:"longitude"`
+		} `json:"results"`
+	}
+	err := getJSON(ctx, geocoding, query(url.Values{
+		"name":     {city},
+		"count":    {"1"},
+		"language": {string(i18n.Current())},
+	}), &places)
+	if err != nil {
+		return nil, err
+	}
+	if len(places.Results) == 0 {
+		return nil, exit.New(exit.NotFound, i18n.Errorf("city %q not found", city))
+	}
+	found := places.Results[0]
+
+	var data struct {
+		Current struct {
+			Temperature float64 `json:"temperature_2m"`
+			FeelsLike   float64 `json:"apparent_temperature"`
+			Humidity    int     `json:"relative_humidity_2m"`
+			Wind        float64 `json:"wind_speed_10m"`
+			Code        int     `json:"weather_code"`
+		} `json:"current"`
+		Daily struct {
+			Time          []string  `json:"time"`
+			Code          []int     `json:"weather_code"`
+			Min           []float64 `json:"temperature_2m_min"`
+			Max           []float64 `json:"temperature_2m_max"`
+			Precipitation []*int    `json:"precipitation_probability_max"`
+		} `json:"daily"`
+	}
+	err = getJSON(ctx, forecast, query(url.Values{
+		"latitude":      {strconv.FormatFloat(found.Latitude, 'f', -1, 64)},
+		"longitude":     {strconv.FormatFloat(found.Longitude, 'f', -1, 64)},
+		"current":       {"temperature_2m,apparent_temperature,relative_humidity_2m,wind_speed_10m,weather_code"},
+		"daily":         {"weather_code,temperature_2m_min,temperature_2m_max,precipitation_probability_max"},
+		"timezone":      {"auto"},
+		"forecast_days": {strconv.Itoa(days)},
+	}), &data)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Report{
+		Place: place(found.Name, found.Admin1, found.Country),
+		Current: Conditions{
+			Summary:     wmoSummary(data.Current.Code),
+			Temperature: data.Current.Temperature,
+			FeelsLike:   data.Current.FeelsLike,
+			Humidity:    data.Current.Humidity,
+			Wind:        data.Current.Wind,
+		},
+		Days: []Day{},
+	}
+	d := data.Daily
+	for i, date := range d.Time {
+		if i >= len(d.Code) || i >= len(d.Min) || i >= len(d.Max) {
+			break
+		}
+		day := Day{Date: date, Summary: wmoSummary(d.Code[i]), Min: d.Min[i], Max: d.Max[i]}
+		if i < len(d.Precipitation) && d.Precipitation[i] != nil {
+			day.Precipitation = *d.Precipitation[i]
+		}
+		r.Days = append(r.Days, day)
+	}
+	return r, nil
+}
+
+// wmoSummary describes a WMO weather interpretation code
+func wmoSummary(code int) string {
+	switch code {
+	case 0:
+		return i18n.T("Clear sky")
+	case 1:
+		return i18n.T("Mainly clear")
+	case 2:
+		return i18n.T("Partly cloudy")
+	case 3:
+		return i18n.T("Overcast")
+	case 45, 48:
+		return i18n.T("Fog")
+	case 51, 53, 55, 56, 57:
+		return i18n.T("Drizzle")
+	case 61, 63, 66, 80, 81:
+		return i18n.T("Rain")
+	case 65, 67, 82:
+		return i18n.T("Heavy rain")
+	case 71, 73, 77, 85:
+		return i18n.T("Snow")
+	case 75, 86:
+		return i18n.T("Heavy snow")
+	case 95:
+		return i18n.T("Thunderstorm")
+	case 96, 99:
+		return i18n.T("Thunderstorm with hail")
+	}
+	return i18n.T("Unknown")
+}
@@ -0,0 +1,155 @@
+package weather
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/hezhizhen/sak/pkg/exit"
+	"github.com/hezhizhen/sak/pkg/i18n"
+)
+
+// openWeatherMap queries https://openweathermap.org, which requires an API
+// key. Its free plan forecasts five days in steps of three hours.
+type openWeatherMap struct {
+	key string
+}
+
+const openWeatherMapURL = "https://api.openweathermap.org/data/2.5/"
+
+type owmWeather struct {
+	ID int `json:"id"`
+}
+
+func (p *openWeatherMap) Fetch(ctx context.Context, city string, days int) (*Report, error) {
+	query := url.Values{"q": {city}, "units": {"metric"}, "appid": {p.key}}
+
+	var current struct {
+		Name string `json:"name"`
+		Sys  struct {
+			Country string `json:"country"`
+		} `json:"sys"`
+		Main struct {
+			Temp      float64 `json:"temp"`
+			FeelsLike float64 `json:"feels_like"`
+			Humidity  int     `json:"humidity"`
+		} `json:"main"`
+		Wind struct {
+			Speed float64 `json:"speed"`
+		} `json:"wind"`
+		Weather []owmWeather `json:"weather"`
+	}
+	if err := getJSON(ctx, openWeatherMapURL+"weather", query, &current); err != nil {
+		var status *statusError
+		if errors.As(err, &status) && status.code == http.StatusNotFound {
+			return nil, exit.New(exit.NotFound, i18n.Errorf("city %q not found", city))
+		}
+		return nil, err
+	}
+
+	var forecast struct {
+		List []struct {
+			Time int64 `json:"dt"`
+			Main struct {
+				Min float64 `json:"temp_min"`
+				Max float64 `json:"temp_max"`
+			} `json:"main"`
+			Weather []owmWeather `json:"weather"`
+			// Pop is the probability of precipitation, from 0 to 1
+			Pop float64 `json:"pop"`
+		} `json:"list"`
+		City struct {
+			// Timezone is the offset from UTC in seconds
+			Timezone int `json:"timezone"`
+		} `json:"city"`
+	}
+	if err := getJSON(ctx, openWeatherMapURL+"forecast", query, &forecast); err != nil {
+		return nil, err
+	}
+
+	r := &Report{
+		Place: place(current.Name, current.Sys.Country),
+		Current: Conditions{
+			Summary:     owmSummary(current.Weather),
+			Temperature: current.Main.Temp,
+			FeelsLike:   current.Main.FeelsLike,
+			Humidity:    current.Main.Humidity,
+			// m/s
+			Wind: current.Wind.Speed * 3.6,
+		},
+		Days: []Day{},
+	}
+	// the steps are grouped by local day, which is summarized by the step
+	// closest to noon
+	zone := time.FixedZone("", forecast.City.Timezone)
+	noon := map[string]time.Duration{}
+	for _, step := range forecast.List {
+		t := time.Unix(step.Time, 0).In(zone)
+		date := t.Format("2006-01-02")
+		fromNoon := t.Sub(time.Date(t.Year(), t.Month(), t.Day(), 12, 0, 0, 0, zone))
+		if fromNoon < 0 {
+			fromNoon = -fromNoon
+		}
+		pop := int(step.Pop*100 + 0.5)
+
+		last := len(r.Days) - 1
+		if last < 0 || r.Days[last].Date != date {
+			if len(r.Days) == days {
+				break
+			}
+			r.Days = append(r.Days, Day{Date: date, Summary: owmSummary(step.Weather), Min: step.Main.Min, Max: step.Main.Max, Precipitation: pop})
+			noon[date] = fromNoon
+			continue
+		}
+		day := &r.Days[last]
+		if step.Main.Min < day.Min {
+			day.Min = step.Main.Min
+		}
+		if step.Main.Max > day.Max {
+			day.Max = step.Main.Max
+		}
+		if pop > day.Precipitation {
+			day.Precipitation = pop
+		}
+		if fromNoon < noon[date] {
+			day.Summary, noon[date] = owmSummary(step.Weather), fromNoon
+		}
+	}
+	return r, nil
+}
+
+// owmSummary describes OpenWeatherMap conditions with the same words as
+// wmoSummary, see https://openweathermap.org/weather-conditions
+func owmSummary(weather []owmWeather) string {
+	if len(weather) == 0 {
+		return wmoSummary(-1)
+	}
+	id := weather[0].ID
+	switch {
+	case id >= 200 && id < 300:
+		return wmoSummary(95)
+	case id >= 300 && id < 400:
+		return wmoSummary(51)
+	case id == 502 || id == 503 || id == 504 || id == 522:
+		return wmoSummary(65)
+	case id >= 500 && id < 600:
+		return wmoSummary(61)
+	case id == 602 || id == 622:
+		return wmoSummary(75)
+	case id >= 600 && id < 700:
+		return wmoSummary(71)
+	case id >= 700 && id < 800:
+		return wmoSummary(45)
+	case id == 800:
+		return wmoSummary(0)
+	case id == 801:
+		return wmoSummary(1)
+	case id == 802:
+		return wmoSummary(2)
+	case id == 803 || id == 804:
+		return wmoSummary(3)
+	}
+	return wmoSummary(-1)
+}
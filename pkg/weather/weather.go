@@ -0,0 +1,137 @@
+package weather
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hezhizhen/sak/pkg/exit"
+	"github.com/hezhizhen/sak/pkg/i18n"
+)
+
+// Names of the supported providers
+const (
+	OpenMeteo      = "open-meteo"
+	OpenWeatherMap = "openweathermap"
+)
+
+// DefaultProvider is the provider used when none is configured, as it
+// requires no API key
+const DefaultProvider = OpenMeteo
+
+// Providers lists the names of the supported providers
+var Providers = []string{OpenMeteo, OpenWeatherMap}
+
+// Report is the current weather of a place and its forecast
+type Report struct {
+	Place   string     `json:"place" yaml:"place"`
+	Current Conditions `json:"current" yaml:"current"`
+	Days    []Day      `json:"days" yaml:"days"`
+}
+
+// Conditions is the weather at a point in time. Temperatures are in °C and
+// the wind speed in km/h.
+type Conditions struct {
+	Summary     string  `json:"summary" yaml:"summary"`
+	Temperature float64 `json:"temperature" yaml:"temperature"`
+	FeelsLike   float64 `json:"feels_like" yaml:"feels_like"`
+	Humidity    int     `json:"humidity" yaml:"humidity"`
+	Wind        float64 `json:"wind" yaml:"wind"`
+}
+
+// Day is the forecast of a day
+type Day struct {
+	Date    string  `json:"date" yaml:"date"`
+	Summary string  `json:"summary" yaml:"summary"`
+	Min     float64 `json:"min" yaml:"min"`
+	Max     float64 `json:"max" yaml:"max"`
+	// Precipitation is the probability of precipitation in percent
+	Precipitation int `json:"precipitation" yaml:"precipitation"`
+}
+
+// Provider fetches the weather of a city
+type Provider interface {
+	Fetch(ctx context.Context, city string, days int) (*Report, error)
+}
+
+// CheckProvider validates the name of a provider
+func CheckProvider(name string) error {
+	for _, p := range Providers {
+		if name == p {
+			return nil
+		}
+	}
+	return exit.New(exit.Usage, i18n.Errorf("unknown weather provider %q (expected open-meteo or openweathermap)", name))
+}
+
+// NewProvider returns the provider called name, the default one if name is
+// empty, authenticating with key if it is not empty
+func NewProvider(name, key string) (Provider, error) {
+	switch name {
+	case "", OpenMeteo:
+		return &openMeteo{key: key}, nil
+	case OpenWeatherMap:
+		if key == "" {
+			return nil, exit.New(exit.Usage, i18n.Errorf("the openweathermap provider requires an API key, set it with sak config set weather-key"))
+		}
+		return &openWeatherMap{key: key}, nil
+	}
+	return nil, CheckProvider(name)
+}
+
+// statusError is the failure of a request. Like the other errors of getJSON,
+// it does not show the query, which may hold the API key.
+type statusError struct {
+	url    string
+	status string
+	code   int
+}
+
+func (e *statusError) Error() string {
+	return i18n.Sprintf("GET %s: %s", e.url, e.status)
+}
+
+// getJSON decodes the response to a GET of base with query into v
+func getJSON(ctx context.Context, base string, query url.Values, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, base+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", "sak")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		// the URL of the error holds the query
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			return i18n.Errorf("GET %s: %w", base, urlErr.Err)
+		}
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return &statusError{url: base, status: resp.Status, code: resp.StatusCode}
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 4*1024*1024))
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return exit.New(exit.ParseError, i18n.Errorf("parse response of %s: %w", base, err))
+	}
+	return nil
+}
+
+// place joins the non-empty parts of the name of a place
+func place(parts ...string) string {
+	var kept []string
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			kept = append(kept, p)
+		}
+	}
+	return strings.Join(kept, ", ")
+}